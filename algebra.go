@@ -0,0 +1,122 @@
+package plane
+
+import "fmt"
+
+// Union fills the coords that are filled on the other surface, as if they were passed to Fill.
+// Returns an error and leaves the surface as is if the surfaces differ in size.
+func (s *Surface) Union(other *Surface) error {
+	if err := s.checkSameSize(other); err != nil {
+		return err
+	}
+	for i := range s.cells {
+		if other.cells[i].isFilled {
+			s.fillAt(i)
+		}
+	}
+	return nil
+}
+
+// Intersect removes the coords that are not filled on the other surface, as if they were passed to Remove, so that
+// only the coords that are filled on both surfaces stay filled.
+// Returns an error and leaves the surface as is if the surfaces differ in size.
+func (s *Surface) Intersect(other *Surface) error {
+	if err := s.checkSameSize(other); err != nil {
+		return err
+	}
+	for i := range s.cells {
+		if !other.cells[i].isFilled {
+			s.removeAt(i)
+		}
+	}
+	return nil
+}
+
+// Difference removes the coords that are filled on the other surface, as if they were passed to Remove.
+// Returns an error and leaves the surface as is if the surfaces differ in size.
+func (s *Surface) Difference(other *Surface) error {
+	if err := s.checkSameSize(other); err != nil {
+		return err
+	}
+	for i := range s.cells {
+		if other.cells[i].isFilled {
+			s.removeAt(i)
+		}
+	}
+	return nil
+}
+
+// Invert fills the coords that are not filled, and removes the coords that are, as if they were passed to Fill and
+// Remove.
+func (s *Surface) Invert() {
+	for i := range s.cells {
+		if s.cells[i].isFilled {
+			s.removeAt(i)
+		} else {
+			s.fillAt(i)
+		}
+	}
+}
+
+// Equal returns true if the other surface is of the same size, wrapping and connectivity, and the same coords are
+// filled on it. Anything else, such as costs and cells, is not compared.
+func (s *Surface) Equal(other *Surface) bool {
+	if s.width != other.width || s.height != other.height || s.wrapped != other.wrapped ||
+		s.connectivity != other.connectivity {
+		return false
+	}
+	for i := range s.cells {
+		if s.cells[i].isFilled != other.cells[i].isFilled {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a clone of surface a, on which the coords that are filled on surface b are filled too.
+// See Surface.Union.
+// Returns an error if the surfaces differ in size.
+func Union(a, b *Surface) (*Surface, error) {
+	clone := a.Clone()
+	if err := clone.Union(b); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Intersect returns a clone of surface a, on which only the coords that are filled on surface b too are filled.
+// See Surface.Intersect.
+// Returns an error if the surfaces differ in size.
+func Intersect(a, b *Surface) (*Surface, error) {
+	clone := a.Clone()
+	if err := clone.Intersect(b); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Difference returns a clone of surface a, on which the coords that are filled on surface b are removed.
+// See Surface.Difference.
+// Returns an error if the surfaces differ in size.
+func Difference(a, b *Surface) (*Surface, error) {
+	clone := a.Clone()
+	if err := clone.Difference(b); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Invert returns a clone of the given surface, on which the coords that are not filled are filled, and the coords
+// that are filled are not. See Surface.Invert.
+func Invert(s *Surface) *Surface {
+	clone := s.Clone()
+	clone.Invert()
+	return clone
+}
+
+// checkSameSize returns an error if the other surface differs in size.
+func (s *Surface) checkSameSize(other *Surface) error {
+	if s.width != other.width || s.height != other.height {
+		return fmt.Errorf("surfaces differ in size: %dx%d and %dx%d", s.width, s.height, other.width, other.height)
+	}
+	return nil
+}
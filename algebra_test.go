@@ -0,0 +1,140 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Surface_Algebra(t *testing.T) {
+	Convey("Surface algebra", t, func() {
+		// x x .    . x x
+		// . . .    . . .
+		// x . .    x x .
+		a := NewSurface(3, 3)
+		a.fillRows([][]int{
+			{1, 1, 0},
+			{0, 0, 0},
+			{1, 0, 0},
+		})
+		b := NewSurface(3, 3)
+		b.fillRows([][]int{
+			{0, 1, 1},
+			{0, 0, 0},
+			{1, 1, 0},
+		})
+		aBefore := a.Clone()
+		bBefore := b.Clone()
+
+		Convey("Union() fills the coords that are filled on either surface", func() {
+			So(a.Union(b), ShouldBeNil)
+
+			So(a.GetFilled().Equals(Coords{{0, 2}, {1, 2}, {2, 2}, {0, 0}, {1, 0}}), ShouldBeTrue)
+			clone, err := Union(aBefore, b)
+			So(err, ShouldBeNil)
+			So(clone.Equal(a), ShouldBeTrue)
+		})
+
+		Convey("Intersect() fills the coords that are filled on both surfaces", func() {
+			So(a.Intersect(b), ShouldBeNil)
+
+			So(a.GetFilled().Equals(Coords{{1, 2}, {0, 0}}), ShouldBeTrue)
+			clone, err := Intersect(aBefore, b)
+			So(err, ShouldBeNil)
+			So(clone.Equal(a), ShouldBeTrue)
+		})
+
+		Convey("Difference() fills the coords that are filled only on the first surface", func() {
+			So(a.Difference(b), ShouldBeNil)
+
+			So(a.GetFilled(), ShouldResemble, Coords{{0, 2}})
+			clone, err := Difference(aBefore, b)
+			So(err, ShouldBeNil)
+			So(clone.Equal(a), ShouldBeTrue)
+		})
+
+		Convey("Invert() fills the coords that are not filled", func() {
+			a.Invert()
+
+			So(a.CountFilled(), ShouldEqual, 6)
+			So(a.IsFilled(Coord{0, 2}), ShouldBeFalse)
+			So(a.IsFilled(Coord{2, 2}), ShouldBeTrue)
+			So(Invert(aBefore).Equal(a), ShouldBeTrue)
+			a.Invert()
+			So(a.Equal(aBefore), ShouldBeTrue)
+		})
+
+		Convey("The functions do not change the given surfaces", func() {
+			Union(a, b)
+			Intersect(a, b)
+			Difference(a, b)
+			Invert(a)
+
+			So(a, ShouldResemble, aBefore)
+			So(b, ShouldResemble, bBefore)
+		})
+
+		Convey("Keeps the costs and cells, like Fill() and Remove() do", func() {
+			a.SetCost(5, Coord{0, 0}, Coord{1, 1})
+			a.SetCell(Cell{Kind: CellFood}, Coord{0, 0}, Coord{1, 1})
+
+			a.Invert()
+
+			So(a.GetCost(Coord{0, 0}), ShouldEqual, 5)
+			So(a.GetCost(Coord{1, 1}), ShouldEqual, 5)
+			So(a.CellsOfKind(CellFood), ShouldResemble, Coords{{0, 0}, {1, 1}})
+		})
+
+		Convey("Fills coords that were filled until a given step forever, like Fill() does", func() {
+			c := NewSurface(3, 3)
+			c.FillUntil(2, Coord{0, 0}, Coord{2, 0})
+
+			So(c.Union(b), ShouldBeNil)
+
+			So(c.IsFilledAt(Coord{0, 0}, 5), ShouldBeTrue)
+			So(c.IsFilledAt(Coord{2, 0}, 5), ShouldBeFalse)
+		})
+
+		Convey("Returns an error if the surfaces differ in size", func() {
+			c := NewSurface(3, 4)
+			c.Fill(Coord{2, 1})
+
+			So(a.Union(c), ShouldNotBeNil)
+			So(a.Intersect(c), ShouldNotBeNil)
+			So(a.Difference(c), ShouldNotBeNil)
+			So(a, ShouldResemble, aBefore)
+
+			for _, fn := range []func(a, b *Surface) (*Surface, error){Union, Intersect, Difference} {
+				clone, err := fn(a, c)
+				So(err, ShouldNotBeNil)
+				So(clone, ShouldBeNil)
+			}
+		})
+
+		Convey("Equal()", func() {
+			Convey("Returns true if the same coords are filled", func() {
+				So(a.Equal(aBefore), ShouldBeTrue)
+				So(a.Equal(b), ShouldBeFalse)
+			})
+
+			Convey("Ignores costs and cells", func() {
+				a.SetCost(3, Coord{1, 1})
+				a.SetCell(Cell{Kind: CellHazard}, Coord{1, 1})
+
+				So(a.Equal(aBefore), ShouldBeTrue)
+			})
+
+			Convey("Returns false if the surfaces differ in size", func() {
+				So(NewSurface(3, 4).Equal(NewSurface(4, 3)), ShouldBeFalse)
+			})
+
+			Convey("Returns false if the surfaces differ in wrapping or connectivity", func() {
+				So(NewSurface(3, 3).Equal(NewWrappedSurface(3, 3)), ShouldBeFalse)
+
+				eightConnected := NewSurface(3, 3)
+				So(eightConnected.SetConnectivity(EightConnected), ShouldBeNil)
+				So(NewSurface(3, 3).Equal(eightConnected), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,33 @@
+// Package battlesnaketest provides game states for testing code that plays Battlesnake.
+package battlesnaketest
+
+import (
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+)
+
+// NewSnake returns a snake with the given health and body. The first coord of the body is the head.
+func NewSnake(id string, health int, body ...plane.Coord) battlesnake.Snake {
+	return battlesnake.Snake{
+		ID:     id,
+		Health: health,
+		Body:   body,
+		Head:   body[0],
+		Length: len(body),
+	}
+}
+
+// NewGameState returns the state of turn 1 of a standard game on a 5x5 board with the given snakes, played as the first
+// snake.
+func NewGameState(snakes ...battlesnake.Snake) *battlesnake.GameState {
+	return &battlesnake.GameState{
+		Game: battlesnake.Game{Ruleset: battlesnake.Ruleset{Name: battlesnake.RulesetStandard}},
+		Turn: 1,
+		Board: battlesnake.Board{
+			Width:  5,
+			Height: 5,
+			Snakes: snakes,
+		},
+		You: snakes[0],
+	}
+}
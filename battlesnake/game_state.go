@@ -0,0 +1,125 @@
+// Package battlesnake turns the requests that the Battlesnake engine sends to a snake into surfaces that can be flood
+// filled and searched. See https://docs.battlesnake.com/api for the API that the types in this package describe.
+package battlesnake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/minitauros/go-plane"
+)
+
+// GameState is the body of the requests that the Battlesnake engine sends to the /start, /move and /end endpoints.
+type GameState struct {
+	Game  Game  `json:"game"`
+	Turn  int   `json:"turn"`
+	Board Board `json:"board"`
+	You   Snake `json:"you"`
+}
+
+// Game describes the game that is being played.
+type Game struct {
+	ID      string  `json:"id"`
+	Ruleset Ruleset `json:"ruleset"`
+	Map     string  `json:"map"`
+	// Timeout is the number of milliseconds the snake has to respond to a request.
+	Timeout int    `json:"timeout"`
+	Source  string `json:"source"`
+}
+
+// Ruleset describes the rules of the game.
+type Ruleset struct {
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	Settings RulesetSettings `json:"settings"`
+}
+
+// Names of the game modes, as found in Ruleset.Name.
+const (
+	RulesetStandard    = "standard"
+	RulesetSolo        = "solo"
+	RulesetRoyale      = "royale"
+	RulesetSquad       = "squad"
+	RulesetConstrictor = "constrictor"
+	RulesetWrapped     = "wrapped"
+)
+
+// RulesetSettings are the settings of the rules of the game.
+type RulesetSettings struct {
+	FoodSpawnChance     int            `json:"foodSpawnChance"`
+	MinimumFood         int            `json:"minimumFood"`
+	HazardDamagePerTurn int            `json:"hazardDamagePerTurn"`
+	Royale              RoyaleSettings `json:"royale"`
+	Squad               SquadSettings  `json:"squad"`
+}
+
+// RoyaleSettings are the settings that are specific to the royale game mode.
+type RoyaleSettings struct {
+	ShrinkEveryNTurns int `json:"shrinkEveryNTurns"`
+}
+
+// SquadSettings are the settings that are specific to the squad game mode.
+type SquadSettings struct {
+	AllowBodyCollisions bool `json:"allowBodyCollisions"`
+	SharedElimination   bool `json:"sharedElimination"`
+	SharedHealth        bool `json:"sharedHealth"`
+	SharedLength        bool `json:"sharedLength"`
+}
+
+// Board is the game board.
+type Board struct {
+	Height  int           `json:"height"`
+	Width   int           `json:"width"`
+	Food    []plane.Coord `json:"food"`
+	Hazards []plane.Coord `json:"hazards"`
+	Snakes  []Snake       `json:"snakes"`
+}
+
+// Snake is a snake on the board.
+type Snake struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Health int    `json:"health"`
+	// Body holds the coords of the snake, from head to tail.
+	Body []plane.Coord `json:"body"`
+	// Latency is the number of milliseconds it took the snake to respond to the previous request.
+	Latency        string         `json:"latency"`
+	Head           plane.Coord    `json:"head"`
+	Length         int            `json:"length"`
+	Shout          string         `json:"shout"`
+	Squad          string         `json:"squad"`
+	Customizations Customizations `json:"customizations"`
+}
+
+// Customizations describe what a snake looks like.
+type Customizations struct {
+	Color string `json:"color"`
+	Head  string `json:"head"`
+	Tail  string `json:"tail"`
+}
+
+// TailWillMove returns true if the tail of the snake will move away next turn. After eating, the tail of a snake stays
+// where it is for a turn, which shows as the last two coords of its body being the same.
+func (s Snake) TailWillMove() bool {
+	n := len(s.Body)
+	return n < 2 || s.Body[n-1] != s.Body[n-2]
+}
+
+// ParseGameState parses the given JSON encoded game state.
+func ParseGameState(data []byte) (*GameState, error) {
+	var state GameState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("could not parse game state: %w", err)
+	}
+	return &state, nil
+}
+
+// DecodeGameState decodes the JSON encoded game state that is read from the given reader, such as a request body.
+func DecodeGameState(r io.Reader) (*GameState, error) {
+	var state GameState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("could not decode game state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,175 @@
+package battlesnake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minitauros/go-plane"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// moveRequest is an example of a /move request body, taken from the Battlesnake API documentation.
+const moveRequest = `{
+  "game": {
+    "id": "totally-unique-game-id",
+    "ruleset": {
+      "name": "standard",
+      "version": "v1.1.15",
+      "settings": {
+        "foodSpawnChance": 15,
+        "minimumFood": 1,
+        "hazardDamagePerTurn": 14,
+        "royale": {
+          "shrinkEveryNTurns": 5
+        },
+        "squad": {
+          "allowBodyCollisions": true,
+          "sharedElimination": true,
+          "sharedHealth": true,
+          "sharedLength": true
+        }
+      }
+    },
+    "map": "standard",
+    "source": "league",
+    "timeout": 500
+  },
+  "turn": 14,
+  "board": {
+    "height": 11,
+    "width": 11,
+    "food": [
+      {"x": 5, "y": 5},
+      {"x": 9, "y": 0},
+      {"x": 2, "y": 6}
+    ],
+    "hazards": [
+      {"x": 3, "y": 2}
+    ],
+    "snakes": [
+      {
+        "id": "snake-508e96ac-94ad-11ea-bb37",
+        "name": "My Snake",
+        "health": 54,
+        "body": [
+          {"x": 0, "y": 0},
+          {"x": 1, "y": 0},
+          {"x": 2, "y": 0}
+        ],
+        "latency": "111",
+        "head": {"x": 0, "y": 0},
+        "length": 3,
+        "shout": "why are we shouting??",
+        "customizations": {
+          "color": "#FF0000",
+          "head": "pixel",
+          "tail": "pixel"
+        }
+      },
+      {
+        "id": "snake-b67f4906-94ae-11ea-bb37",
+        "name": "Another Snake",
+        "health": 16,
+        "body": [
+          {"x": 5, "y": 4},
+          {"x": 5, "y": 3},
+          {"x": 6, "y": 3},
+          {"x": 6, "y": 2},
+          {"x": 6, "y": 2}
+        ],
+        "latency": "222",
+        "head": {"x": 5, "y": 4},
+        "length": 5,
+        "shout": "I'm not really sure...",
+        "customizations": {
+          "color": "#26CF04",
+          "head": "silly",
+          "tail": "curled"
+        }
+      }
+    ]
+  },
+  "you": {
+    "id": "snake-508e96ac-94ad-11ea-bb37",
+    "name": "My Snake",
+    "health": 54,
+    "body": [
+      {"x": 0, "y": 0},
+      {"x": 1, "y": 0},
+      {"x": 2, "y": 0}
+    ],
+    "latency": "111",
+    "head": {"x": 0, "y": 0},
+    "length": 3,
+    "shout": "why are we shouting??",
+    "customizations": {
+      "color": "#FF0000",
+      "head": "pixel",
+      "tail": "pixel"
+    }
+  }
+}`
+
+func Test_ParseGameState(t *testing.T) {
+	Convey("ParseGameState()", t, func() {
+		Convey("Parses a move request", func() {
+			state, err := ParseGameState([]byte(moveRequest))
+
+			So(err, ShouldBeNil)
+			So(state.Game.ID, ShouldEqual, "totally-unique-game-id")
+			So(state.Game.Ruleset.Name, ShouldEqual, RulesetStandard)
+			So(state.Game.Ruleset.Settings.HazardDamagePerTurn, ShouldEqual, 14)
+			So(state.Game.Ruleset.Settings.Royale.ShrinkEveryNTurns, ShouldEqual, 5)
+			So(state.Game.Ruleset.Settings.Squad.SharedHealth, ShouldBeTrue)
+			So(state.Game.Timeout, ShouldEqual, 500)
+			So(state.Turn, ShouldEqual, 14)
+			So(state.Board.Width, ShouldEqual, 11)
+			So(state.Board.Height, ShouldEqual, 11)
+			So(state.Board.Food, ShouldResemble, []plane.Coord{{X: 5, Y: 5}, {X: 9, Y: 0}, {X: 2, Y: 6}})
+			So(state.Board.Hazards, ShouldResemble, []plane.Coord{{X: 3, Y: 2}})
+			So(state.Board.Snakes, ShouldHaveLength, 2)
+			So(state.Board.Snakes[1].Head, ShouldResemble, plane.Coord{X: 5, Y: 4})
+			So(state.Board.Snakes[1].Customizations.Tail, ShouldEqual, "curled")
+			So(state.You.ID, ShouldEqual, state.Board.Snakes[0].ID)
+			So(state.You.Body, ShouldResemble, []plane.Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}})
+		})
+
+		Convey("Returns an error for invalid JSON", func() {
+			_, err := ParseGameState([]byte(`{"turn": "one"}`))
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_DecodeGameState(t *testing.T) {
+	Convey("DecodeGameState()", t, func() {
+		Convey("Decodes a move request", func() {
+			state, err := DecodeGameState(strings.NewReader(moveRequest))
+
+			So(err, ShouldBeNil)
+			So(state.You.Name, ShouldEqual, "My Snake")
+		})
+
+		Convey("Returns an error for invalid JSON", func() {
+			_, err := DecodeGameState(strings.NewReader(`{`))
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_Snake_TailWillMove(t *testing.T) {
+	Convey("Snake.TailWillMove()", t, func() {
+		state, err := ParseGameState([]byte(moveRequest))
+		So(err, ShouldBeNil)
+
+		Convey("Returns true if the snake did not just eat", func() {
+			So(state.Board.Snakes[0].TailWillMove(), ShouldBeTrue)
+		})
+
+		Convey("Returns false if the snake just ate", func() {
+			So(state.Board.Snakes[1].TailWillMove(), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,238 @@
+package search
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+)
+
+const (
+	// defaultExploration is the UCB1 exploration constant that is used if no other is given.
+	defaultExploration = math.Sqrt2
+	// defaultRolloutDepth is the number of random turns that are played after expanding the tree, if no other
+	// number is given.
+	defaultRolloutDepth = 10
+)
+
+// MCTS finds the best move using Monte Carlo tree search. Because snakes move simultaneously, every snake picks its
+// own move in each node of the tree, based on its own statistics (decoupled UCT). Each iteration expands the tree by
+// one node, plays random turns from there, and evaluates the outcome for every snake.
+type MCTS struct {
+	// Evaluate scores the game states at the end of each rollout. Defaults to Area.
+	Evaluate Evaluator
+	// Exploration is the UCB1 exploration constant. Defaults to the square root of 2.
+	Exploration float64
+	// RolloutDepth is the number of random turns to play after expanding the tree. Defaults to 10.
+	RolloutDepth int
+	// MaxIterations is the number of iterations to complete at most. Defaults to no maximum.
+	MaxIterations int
+	// Rand is used to pick random moves. Defaults to a source seeded with the current time.
+	Rand *rand.Rand
+}
+
+// Search returns the best move for the snake in state.You, within the given time budget, or before the context is
+// done if that comes first. The best move is the one that was explored most often.
+func (m MCTS) Search(ctx context.Context, state *battlesnake.GameState, budget time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	s := mctsSearch{
+		MCTS: m,
+		solo: len(state.Board.Snakes) <= 1,
+	}
+	if s.Evaluate == nil {
+		s.Evaluate = Area
+	}
+	if s.Exploration == 0 {
+		s.Exploration = defaultExploration
+	}
+	if s.RolloutDepth == 0 {
+		s.RolloutDepth = defaultRolloutDepth
+	}
+	if s.Rand == nil {
+		s.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	root := s.newNode(state)
+	var iterations int
+	for ctx.Err() == nil && (s.MaxIterations == 0 || iterations < s.MaxIterations) {
+		s.iterate(root)
+		iterations++
+	}
+
+	return root.getResult(state.You.ID, iterations)
+}
+
+type mctsSearch struct {
+	MCTS
+	solo bool
+}
+
+// mctsNode is a node in the search tree. It keeps statistics for each move of each snake that is on the board.
+type mctsNode struct {
+	state    *battlesnake.GameState
+	terminal bool
+	visits   int
+	snakeIDs []string
+	moves    [][]plane.Direction
+	// moveVisits and moveRewards hold, per snake and per move, how often the move was picked and the sum of the
+	// rewards that followed.
+	moveVisits  [][]int
+	moveRewards [][]float64
+	// children holds the child nodes by the indexes of the moves that the snakes picked.
+	children map[string]*mctsNode
+}
+
+func (s mctsSearch) newNode(state *battlesnake.GameState) *mctsNode {
+	n := &mctsNode{
+		state:    state,
+		terminal: isOver(state, s.solo),
+		children: make(map[string]*mctsNode),
+	}
+	for _, snake := range state.Board.Snakes {
+		moves := getMoves(state, snake)
+		n.snakeIDs = append(n.snakeIDs, snake.ID)
+		n.moves = append(n.moves, moves)
+		n.moveVisits = append(n.moveVisits, make([]int, len(moves)))
+		n.moveRewards = append(n.moveRewards, make([]float64, len(moves)))
+	}
+	return n
+}
+
+// iterate walks down the tree until it expands a new node or reaches the end of the game, then plays random turns
+// and updates the statistics of the nodes that it passed.
+func (s mctsSearch) iterate(root *mctsNode) {
+	type step struct {
+		node    *mctsNode
+		indexes []int
+	}
+	var path []step
+
+	n := root
+	var rewards map[string]float64
+	for {
+		if n.terminal {
+			rewards = s.getRewards(n.state)
+			break
+		}
+		indexes := s.selectMoves(n)
+		path = append(path, step{n, indexes})
+
+		key := getKey(indexes)
+		child, ok := n.children[key]
+		if !ok {
+			next, _ := battlesnake.SimulateTurn(n.state, n.getMoves(indexes))
+			child = s.newNode(next)
+			n.children[key] = child
+			child.visits++
+			rewards = s.rollout(next)
+			break
+		}
+		n = child
+	}
+
+	for _, step := range path {
+		step.node.visits++
+		for i, moveIndex := range step.indexes {
+			step.node.moveVisits[i][moveIndex]++
+			step.node.moveRewards[i][moveIndex] += rewards[step.node.snakeIDs[i]]
+		}
+	}
+}
+
+// selectMoves picks a move for each snake in the given node, using UCB1. Moves that were never picked go first, in
+// random order.
+func (s mctsSearch) selectMoves(n *mctsNode) []int {
+	indexes := make([]int, len(n.snakeIDs))
+	for i := range n.snakeIDs {
+		var unvisited []int
+		for j := range n.moves[i] {
+			if n.moveVisits[i][j] == 0 {
+				unvisited = append(unvisited, j)
+			}
+		}
+		if len(unvisited) > 0 {
+			indexes[i] = unvisited[s.Rand.Intn(len(unvisited))]
+			continue
+		}
+
+		best := math.Inf(-1)
+		for j := range n.moves[i] {
+			visits := float64(n.moveVisits[i][j])
+			value := n.moveRewards[i][j]/visits + s.Exploration*math.Sqrt(math.Log(float64(n.visits))/visits)
+			if value > best {
+				best = value
+				indexes[i] = j
+			}
+		}
+	}
+	return indexes
+}
+
+// rollout plays random turns starting at the given state, and returns the rewards of all snakes afterwards.
+func (s mctsSearch) rollout(state *battlesnake.GameState) map[string]float64 {
+	for i := 0; i < s.RolloutDepth && !isOver(state, s.solo); i++ {
+		moves := make(map[string]plane.Direction, len(state.Board.Snakes))
+		for _, snake := range state.Board.Snakes {
+			options := getMoves(state, snake)
+			moves[snake.ID] = options[s.Rand.Intn(len(options))]
+		}
+		state, _ = battlesnake.SimulateTurn(state, moves)
+	}
+	return s.getRewards(state)
+}
+
+// getRewards returns the score of every snake on the board. Snakes that are no longer on the board get no reward.
+func (s mctsSearch) getRewards(state *battlesnake.GameState) map[string]float64 {
+	rewards := make(map[string]float64, len(state.Board.Snakes))
+	for _, snake := range state.Board.Snakes {
+		rewards[snake.ID] = score(state, snake.ID, s.Evaluate, s.solo)
+	}
+	return rewards
+}
+
+// getMoves returns the moves by snake ID for the given move indexes.
+func (n *mctsNode) getMoves(indexes []int) map[string]plane.Direction {
+	moves := make(map[string]plane.Direction, len(indexes))
+	for i, moveIndex := range indexes {
+		moves[n.snakeIDs[i]] = n.moves[i][moveIndex]
+	}
+	return moves
+}
+
+// getResult returns the move of the given snake that was picked most often.
+func (n *mctsNode) getResult(snakeID string, iterations int) Result {
+	res := Result{Direction: plane.Top, Iterations: iterations}
+	for i, id := range n.snakeIDs {
+		if id != snakeID {
+			continue
+		}
+		res.Direction = n.moves[i][0]
+		bestVisits := -1
+		for j, d := range n.moves[i] {
+			if n.moveVisits[i][j] <= bestVisits {
+				continue
+			}
+			bestVisits = n.moveVisits[i][j]
+			res.Direction = d
+			if bestVisits > 0 {
+				res.Score = n.moveRewards[i][j] / float64(bestVisits)
+			}
+		}
+	}
+	return res
+}
+
+func getKey(indexes []int) string {
+	parts := make([]string, len(indexes))
+	for i, index := range indexes {
+		parts[i] = strconv.Itoa(index)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+)
+
+// defaultMaxDepth is the number of turns that minimax looks ahead at most, if no maximum is given.
+const defaultMaxDepth = 32
+
+// Minimax finds the best move using paranoid minimax with alpha-beta pruning: it assumes that all other snakes work
+// together against the snake that is searching for, and thus picks the move of which the worst outcome is best.
+// It searches one turn deeper each time, for as long as the time budget allows.
+type Minimax struct {
+	// Evaluate scores the game states at the maximum depth. Defaults to Area.
+	Evaluate Evaluator
+	// MaxDepth is the number of turns to look ahead at most. Defaults to 32.
+	MaxDepth int
+}
+
+// Search returns the best move for the snake in state.You, within the given time budget, or before the context is
+// done if that comes first. The result is that of the deepest search that was completed.
+func (m Minimax) Search(ctx context.Context, state *battlesnake.GameState, budget time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	maxDepth := m.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultMaxDepth
+	}
+	evaluate := m.Evaluate
+	if evaluate == nil {
+		evaluate = Area
+	}
+	s := &minimaxSearch{
+		ctx:      ctx,
+		evaluate: evaluate,
+		youID:    state.You.ID,
+		solo:     len(state.Board.Snakes) <= 1,
+	}
+
+	best := Result{Direction: getMoves(state, state.You)[0]}
+	for depth := 1; depth <= maxDepth; depth++ {
+		s.cutOff = false
+		d, score, ok := s.searchRoot(state, depth)
+		if !ok {
+			break
+		}
+		best = Result{Direction: d, Score: score, Depth: depth}
+		if !s.cutOff {
+			// Every line that was searched ended the game, so looking further ahead does not help.
+			break
+		}
+	}
+	return best
+}
+
+type minimaxSearch struct {
+	ctx      context.Context
+	evaluate Evaluator
+	youID    string
+	solo     bool
+	// cutOff is set when a game state is evaluated because the maximum depth was reached, before the game was over.
+	cutOff bool
+}
+
+// searchRoot returns the best direction and its score when looking the given number of turns ahead.
+// Returns false if the search ran out of time.
+func (s *minimaxSearch) searchRoot(state *battlesnake.GameState, depth int) (plane.Direction, float64, bool) {
+	alpha := math.Inf(-1)
+	moves := getMoves(state, state.You)
+	best := moves[0]
+	for _, d := range moves {
+		score, ok := s.minOverOpponents(state, d, depth, alpha, math.Inf(1))
+		if !ok {
+			return "", 0, false
+		}
+		if score > alpha {
+			alpha = score
+			best = d
+		}
+	}
+	return best, alpha, true
+}
+
+// max returns the score of the best move of the searching snake.
+func (s *minimaxSearch) max(state *battlesnake.GameState, depth int, alpha, beta float64) (float64, bool) {
+	if s.ctx.Err() != nil {
+		return 0, false
+	}
+	if _, ok := findSnake(state, s.youID); !ok || isOver(state, s.solo) {
+		return score(state, s.youID, s.evaluate, s.solo), true
+	}
+	if depth == 0 {
+		s.cutOff = true
+		return score(state, s.youID, s.evaluate, s.solo), true
+	}
+	value := math.Inf(-1)
+	for _, d := range getMoves(state, state.You) {
+		score, ok := s.minOverOpponents(state, d, depth, alpha, beta)
+		if !ok {
+			return 0, false
+		}
+		value = math.Max(value, score)
+		alpha = math.Max(alpha, value)
+		if alpha >= beta {
+			break
+		}
+	}
+	return value, true
+}
+
+// minOverOpponents returns the score of the worst combination of moves of the other snakes, given that the searching
+// snake moves in direction d.
+func (s *minimaxSearch) minOverOpponents(
+	state *battlesnake.GameState,
+	d plane.Direction,
+	depth int,
+	alpha, beta float64,
+) (float64, bool) {
+	value := math.Inf(1)
+	for _, moves := range getOpponentMoves(state, s.youID) {
+		moves[s.youID] = d
+		next, _ := battlesnake.SimulateTurn(state, moves)
+		score, ok := s.max(next, depth-1, alpha, beta)
+		if !ok {
+			return 0, false
+		}
+		value = math.Min(value, score)
+		beta = math.Min(beta, value)
+		if alpha >= beta {
+			break
+		}
+	}
+	return value, true
+}
+
+// getOpponentMoves returns all combinations of moves of the snakes other than the given snake.
+func getOpponentMoves(state *battlesnake.GameState, youID string) []map[string]plane.Direction {
+	combinations := []map[string]plane.Direction{{}}
+	for _, snake := range state.Board.Snakes {
+		if snake.ID == youID {
+			continue
+		}
+		var extended []map[string]plane.Direction
+		for _, combination := range combinations {
+			for _, d := range getMoves(state, snake) {
+				moves := make(map[string]plane.Direction, len(combination)+2)
+				for id, move := range combination {
+					moves[id] = move
+				}
+				moves[snake.ID] = d
+				extended = append(extended, moves)
+			}
+		}
+		combinations = extended
+	}
+	return combinations
+}
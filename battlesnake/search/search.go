@@ -0,0 +1,89 @@
+// Package search looks ahead in Battlesnake games, by simulating turns with battlesnake.SimulateTurn, to find the best
+// move for a snake. It offers paranoid minimax with alpha-beta pruning, and Monte Carlo tree search.
+package search
+
+import (
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+)
+
+// Evaluator returns how good the given game state is for the snake with the given ID, ranging from 0 (lost) to 1
+// (won). It is only called for snakes that are still on the board.
+type Evaluator func(state *battlesnake.GameState, snakeID string) float64
+
+// Result is the outcome of a search.
+type Result struct {
+	// Direction is the best direction to move in.
+	Direction plane.Direction
+	// Score is the expected score of moving in Direction, ranging from 0 (lost) to 1 (won).
+	Score float64
+	// Depth is the number of turns that minimax looked ahead.
+	Depth int
+	// Iterations is the number of iterations that Monte Carlo tree search completed.
+	Iterations int
+}
+
+// Area is an Evaluator that scores a snake by the part of the board that it can reach. Tails that will move away next
+// turn are not counted as obstacles.
+func Area(state *battlesnake.GameState, snakeID string) float64 {
+	snake, ok := findSnake(state, snakeID)
+	if !ok {
+		return 0
+	}
+	s := battlesnake.NewSurface(state, battlesnake.SurfaceOptions{FreeMovingTails: true})
+	numReachable := len(plane.NewFloodFiller(s).DistanceField(snake.Head).Reachable())
+	return float64(numReachable) / float64(s.TotalSurface())
+}
+
+// score returns how good the given game state is for the given snake. Snakes that were eliminated score 0, and the
+// last snake on the board scores 1, unless the game was played alone from the start.
+func score(state *battlesnake.GameState, snakeID string, evaluate Evaluator, solo bool) float64 {
+	if _, ok := findSnake(state, snakeID); !ok {
+		return 0
+	}
+	if !solo && len(state.Board.Snakes) == 1 {
+		return 1
+	}
+	return evaluate(state, snakeID)
+}
+
+// isOver returns true if no more turns can be played.
+func isOver(state *battlesnake.GameState, solo bool) bool {
+	if solo {
+		return len(state.Board.Snakes) == 0
+	}
+	return len(state.Board.Snakes) <= 1
+}
+
+// getMoves returns the directions that the given snake can move in without moving off the board or back into its own
+// neck. If there are no such directions, it returns up, as the snake is lost anyway.
+func getMoves(state *battlesnake.GameState, snake battlesnake.Snake) []plane.Direction {
+	s := plane.NewSurface(state.Board.Width, state.Board.Height)
+	if state.Game.Ruleset.Name == battlesnake.RulesetWrapped {
+		s = plane.NewWrappedSurface(state.Board.Width, state.Board.Height)
+	}
+	moves := make([]plane.Direction, 0, 4)
+	for _, d := range plane.GetAllDirections() {
+		next := s.Wrap(snake.Head.GetCoordInDirection(d))
+		if !s.Fits(next) {
+			continue
+		}
+		if len(snake.Body) > 1 && next == snake.Body[1] {
+			continue
+		}
+		moves = append(moves, d)
+	}
+	if len(moves) == 0 {
+		moves = append(moves, plane.Top)
+	}
+	return moves
+}
+
+func findSnake(state *battlesnake.GameState, snakeID string) (battlesnake.Snake, bool) {
+	for _, snake := range state.Board.Snakes {
+		if snake.ID == snakeID {
+			return snake, true
+		}
+	}
+	return battlesnake.Snake{}, false
+}
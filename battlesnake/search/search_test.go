@@ -0,0 +1,144 @@
+package search
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+	"github.com/minitauros/go-plane/battlesnake/battlesnaketest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newHeadToHeadState returns a state in which moving up lets the longer opponent win head-to-head.
+func newHeadToHeadState() *battlesnake.GameState {
+	return battlesnaketest.NewGameState(
+		battlesnaketest.NewSnake("you", 100, plane.Coord{X: 2, Y: 2}, plane.Coord{X: 2, Y: 1}, plane.Coord{X: 2, Y: 0}),
+		battlesnaketest.NewSnake(
+			"opponent",
+			100,
+			plane.Coord{X: 2, Y: 4},
+			plane.Coord{X: 3, Y: 4},
+			plane.Coord{X: 4, Y: 4},
+			plane.Coord{X: 4, Y: 3},
+			plane.Coord{X: 4, Y: 2},
+		),
+	)
+}
+
+func Test_Area(t *testing.T) {
+	Convey("Area()", t, func() {
+		Convey("Returns the part of the board that the snake can reach", func() {
+			state := battlesnaketest.NewGameState(battlesnaketest.NewSnake("you", 100,
+				plane.Coord{X: 0, Y: 0}, plane.Coord{X: 1, Y: 0}, plane.Coord{X: 2, Y: 0},
+			))
+
+			// The tail moves away, so only the head and neck are obstacles.
+			So(Area(state, "you"), ShouldAlmostEqual, 23.0/25)
+		})
+
+		Convey("Returns 0 for snakes that are not on the board", func() {
+			state := battlesnaketest.NewGameState(battlesnaketest.NewSnake("you", 100, plane.Coord{X: 0, Y: 0}))
+
+			So(Area(state, "other"), ShouldEqual, 0)
+		})
+	})
+}
+
+func Test_getMoves(t *testing.T) {
+	Convey("getMoves()", t, func() {
+		Convey("Leaves out moves off the board and back into the neck", func() {
+			you := battlesnaketest.NewSnake("you", 100, plane.Coord{X: 0, Y: 0}, plane.Coord{X: 1, Y: 0})
+			state := battlesnaketest.NewGameState(you)
+
+			So(getMoves(state, state.You), ShouldResemble, []plane.Direction{plane.Top})
+		})
+
+		Convey("Keeps moves across the edges in wrapped games", func() {
+			you := battlesnaketest.NewSnake("you", 100, plane.Coord{X: 0, Y: 0}, plane.Coord{X: 1, Y: 0})
+			state := battlesnaketest.NewGameState(you)
+			state.Game.Ruleset.Name = battlesnake.RulesetWrapped
+
+			So(getMoves(state, state.You), ShouldResemble, []plane.Direction{plane.Top, plane.Bot, plane.Left})
+		})
+	})
+}
+
+func Test_Minimax_Search(t *testing.T) {
+	Convey("Minimax.Search()", t, func() {
+		Convey("Avoids losing head-to-head", func() {
+			res := Minimax{MaxDepth: 2}.Search(context.Background(), newHeadToHeadState(), time.Second)
+
+			So(res.Direction, ShouldNotEqual, plane.Top)
+			So(res.Depth, ShouldEqual, 2)
+			So(res.Score, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("Stops looking further ahead when the outcome is certain", func() {
+			state := battlesnaketest.NewGameState(
+				battlesnaketest.NewSnake("you", 100, plane.Coord{X: 0, Y: 2}, plane.Coord{X: 0, Y: 1}, plane.Coord{X: 0, Y: 0}),
+				battlesnaketest.NewSnake("opponent", 100, plane.Coord{X: 4, Y: 4}),
+			)
+			state.Board.Snakes[1].Health = 1
+
+			res := Minimax{}.Search(context.Background(), state, time.Second)
+
+			So(res.Score, ShouldEqual, 1)
+			So(res.Depth, ShouldEqual, 1)
+		})
+
+		Convey("Keeps looking further ahead when the evaluator is certain, but the game is not over", func() {
+			alwaysWon := func(state *battlesnake.GameState, snakeID string) float64 {
+				return 1
+			}
+
+			res := Minimax{Evaluate: alwaysWon, MaxDepth: 3}.Search(context.Background(), newHeadToHeadState(), time.Second)
+
+			So(res.Depth, ShouldEqual, 3)
+		})
+
+		Convey("Uses the given evaluator", func() {
+			preferRight := func(state *battlesnake.GameState, snakeID string) float64 {
+				snake, _ := findSnake(state, snakeID)
+				return float64(snake.Head.X) / 10
+			}
+
+			res := Minimax{Evaluate: preferRight, MaxDepth: 1}.Search(context.Background(), newHeadToHeadState(), time.Second)
+
+			So(res.Direction, ShouldEqual, plane.Right)
+			So(res.Score, ShouldAlmostEqual, 0.3)
+		})
+
+		Convey("Stays within the time budget", func() {
+			start := time.Now()
+			res := Minimax{}.Search(context.Background(), newHeadToHeadState(), 20*time.Millisecond)
+
+			So(time.Since(start), ShouldBeLessThan, 200*time.Millisecond)
+			So(res.Direction, ShouldNotBeEmpty)
+		})
+	})
+}
+
+func Test_MCTS_Search(t *testing.T) {
+	Convey("MCTS.Search()", t, func() {
+		Convey("Avoids losing head-to-head", func() {
+			m := MCTS{MaxIterations: 1000, Rand: rand.New(rand.NewSource(1))}
+
+			res := m.Search(context.Background(), newHeadToHeadState(), 10*time.Second)
+
+			So(res.Direction, ShouldNotEqual, plane.Top)
+			So(res.Iterations, ShouldEqual, 1000)
+			So(res.Score, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("Stays within the time budget", func() {
+			start := time.Now()
+			res := MCTS{}.Search(context.Background(), newHeadToHeadState(), 20*time.Millisecond)
+
+			So(time.Since(start), ShouldBeLessThan, 200*time.Millisecond)
+			So(res.Iterations, ShouldBeGreaterThan, 0)
+		})
+	})
+}
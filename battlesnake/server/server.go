@@ -0,0 +1,234 @@
+// Package server serves a Battlesnake over HTTP. It decodes the requests of the Battlesnake engine, hands a ready
+// surface and flood filler to a Strategy, and responds with the move that the strategy chooses.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+)
+
+const (
+	// defaultTimeout is the time a snake has to respond, if the game state does not tell.
+	defaultTimeout = 500 * time.Millisecond
+	// defaultTimeoutBuffer is the time that is kept free for sending the response back to the engine.
+	defaultTimeoutBuffer = 100 * time.Millisecond
+	// minMoveTime is the least time a strategy gets to choose a move, however large the timeout buffer.
+	minMoveTime = 10 * time.Millisecond
+)
+
+// moves maps the directions that can be taken to the moves of the Battlesnake API.
+var moves = map[plane.Direction]string{
+	plane.Top:   "up",
+	plane.Bot:   "down",
+	plane.Left:  "left",
+	plane.Right: "right",
+}
+
+// Info describes what the snake looks like. It is the response to GET /.
+type Info struct {
+	APIVersion string `json:"apiversion"`
+	Author     string `json:"author,omitempty"`
+	Color      string `json:"color,omitempty"`
+	Head       string `json:"head,omitempty"`
+	Tail       string `json:"tail,omitempty"`
+	Version    string `json:"version,omitempty"`
+}
+
+// Move is the move that a strategy chooses.
+type Move struct {
+	Direction plane.Direction
+	// Shout is shown to the other snakes.
+	Shout string
+}
+
+// moveResponse is the response to POST /move.
+type moveResponse struct {
+	Move  string `json:"move"`
+	Shout string `json:"shout,omitempty"`
+}
+
+// Turn is everything a strategy needs to choose a move.
+type Turn struct {
+	State *battlesnake.GameState
+	// Surface is the board of the game state, built with the surface options of the server.
+	// It is not used by anything else, so the strategy is free to change it.
+	Surface *plane.Surface
+	// FloodFiller works on Surface.
+	FloodFiller *plane.FloodFiller
+}
+
+// Strategy decides how a snake plays.
+type Strategy interface {
+	// Info returns what the snake looks like.
+	Info() Info
+	// Start is called when a game starts.
+	Start(state *battlesnake.GameState)
+	// Move returns the move to make this turn. The context is cancelled when the time to respond is up. If Move does
+	// not return by then, a move that does not run into a filled coord is made instead, if there is one.
+	Move(ctx context.Context, turn Turn) Move
+	// End is called when a game ends.
+	End(state *battlesnake.GameState)
+}
+
+// Options configure a server.
+type Options struct {
+	// SurfaceOptions define which coords are filled on the surface that is handed to the strategy.
+	SurfaceOptions battlesnake.SurfaceOptions
+	// TimeoutBuffer is the time before the move timeout of the game at which the server stops waiting for the
+	// strategy, to leave time for sending the response. Defaults to 100ms. If the buffer leaves less than 10ms, the
+	// strategy gets 10ms anyway, and a negative buffer gives the strategy no more than the move timeout of the game.
+	TimeoutBuffer time.Duration
+}
+
+// Server is an http.Handler that serves the /, /start, /move and /end endpoints of the Battlesnake API.
+type Server struct {
+	strategy Strategy
+	opts     Options
+	mux      *http.ServeMux
+}
+
+// New returns a new server that plays using the given strategy.
+func New(strategy Strategy, opts Options) *Server {
+	if opts.TimeoutBuffer == 0 {
+		opts.TimeoutBuffer = defaultTimeoutBuffer
+	}
+	s := &Server{
+		strategy: strategy,
+		opts:     opts,
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/", s.handleInfo)
+	s.mux.HandleFunc("/start", s.handleStart)
+	s.mux.HandleFunc("/move", s.handleMove)
+	s.mux.HandleFunc("/end", s.handleEnd)
+	return s
+}
+
+// ServeHTTP satisfies http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	info := s.strategy.Info()
+	if info.APIVersion == "" {
+		info.APIVersion = "1"
+	}
+	writeJSON(w, info)
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	state, ok := decodeGameState(w, r)
+	if !ok {
+		return
+	}
+	s.strategy.Start(state)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleMove(w http.ResponseWriter, r *http.Request) {
+	state, ok := decodeGameState(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.getMoveTime(state))
+	defer cancel()
+
+	surface := battlesnake.NewSurface(state, s.opts.SurfaceOptions)
+	// Decide on the fallback move before the strategy gets to change the surface.
+	fallback := getFallbackMove(surface, state.You.Head)
+	turn := Turn{
+		State:       state,
+		Surface:     surface,
+		FloodFiller: plane.NewFloodFiller(surface),
+	}
+
+	// Buffered, so that a strategy that returns too late does not block forever.
+	ch := make(chan Move, 1)
+	go func() {
+		ch <- s.strategy.Move(ctx, turn)
+	}()
+
+	var move Move
+	select {
+	case move = <-ch:
+	case <-ctx.Done():
+		move = fallback
+	}
+	name, ok := moves[move.Direction]
+	if !ok {
+		name = moves[fallback.Direction]
+	}
+	writeJSON(w, moveResponse{
+		Move:  name,
+		Shout: move.Shout,
+	})
+}
+
+func (s *Server) handleEnd(w http.ResponseWriter, r *http.Request) {
+	state, ok := decodeGameState(w, r)
+	if !ok {
+		return
+	}
+	s.strategy.End(state)
+	w.WriteHeader(http.StatusOK)
+}
+
+// getMoveTime returns how long the strategy gets to choose a move in the given game state: the move timeout of the game
+// minus the timeout buffer, but no less than minMoveTime and no more than the move timeout.
+func (s *Server) getMoveTime(state *battlesnake.GameState) time.Duration {
+	timeout := time.Duration(state.Game.Timeout) * time.Millisecond
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	moveTime := timeout - s.opts.TimeoutBuffer
+	if moveTime < minMoveTime {
+		return minMoveTime
+	}
+	if moveTime > timeout {
+		return timeout
+	}
+	return moveTime
+}
+
+// getFallbackMove returns a move that does not run into a filled coord, or a move up if there is no such move.
+func getFallbackMove(surface *plane.Surface, head plane.Coord) Move {
+	for _, d := range plane.GetAllDirections() {
+		if !surface.IsFilled(head.GetCoordInDirection(d)) {
+			return Move{Direction: d}
+		}
+	}
+	return Move{Direction: plane.Top}
+}
+
+// decodeGameState decodes the game state in the body of the given request. If it cannot be decoded, it responds with
+// an error and returns false.
+func decodeGameState(w http.ResponseWriter, r *http.Request) (*battlesnake.GameState, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	state, err := battlesnake.DecodeGameState(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return state, true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// moveRequest is a /move request body on a 3x3 board, on which the only move that does not run into a filled coord is
+// up.
+const moveRequest = `{
+  "game": {"id": "game-id", "ruleset": {"name": "standard"}, "timeout": %d},
+  "turn": 3,
+  "board": {
+    "height": 3,
+    "width": 3,
+    "snakes": [
+      {"id": "you", "body": [{"x": 0, "y": 0}, {"x": 1, "y": 0}, {"x": 2, "y": 0}], "head": {"x": 0, "y": 0}}
+    ]
+  },
+  "you": {"id": "you", "body": [{"x": 0, "y": 0}, {"x": 1, "y": 0}, {"x": 2, "y": 0}], "head": {"x": 0, "y": 0}}
+}`
+
+type fakeStrategy struct {
+	move    Move
+	delay   time.Duration
+	turn    Turn
+	started *battlesnake.GameState
+	ended   *battlesnake.GameState
+}
+
+func (f *fakeStrategy) Info() Info {
+	return Info{Author: "minitauros", Color: "#FF0000"}
+}
+
+func (f *fakeStrategy) Start(state *battlesnake.GameState) {
+	f.started = state
+}
+
+func (f *fakeStrategy) Move(ctx context.Context, turn Turn) Move {
+	f.turn = turn
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+	}
+	return f.move
+}
+
+func (f *fakeStrategy) End(state *battlesnake.GameState) {
+	f.ended = state
+}
+
+// newMoveRequest returns a /move request for a game with the given timeout in milliseconds.
+func newMoveRequest(timeout int) *http.Request {
+	body := fmt.Sprintf(moveRequest, timeout)
+	return httptest.NewRequest(http.MethodPost, "/move", strings.NewReader(body))
+}
+
+func Test_Server(t *testing.T) {
+	Convey("Server", t, func() {
+		strategy := &fakeStrategy{move: Move{Direction: plane.Right, Shout: "hi"}}
+		server := New(strategy, Options{TimeoutBuffer: 50 * time.Millisecond})
+		rec := httptest.NewRecorder()
+
+		Convey("GET /", func() {
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			Convey("Responds with the info of the strategy", func() {
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(rec.Header().Get("Content-Type"), ShouldEqual, "application/json")
+				var info Info
+				So(json.Unmarshal(rec.Body.Bytes(), &info), ShouldBeNil)
+				So(info, ShouldResemble, Info{APIVersion: "1", Author: "minitauros", Color: "#FF0000"})
+			})
+		})
+
+		Convey("POST /start", func() {
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/start", strings.NewReader(`{"turn": 0}`)))
+
+			Convey("Starts the strategy", func() {
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(strategy.started, ShouldNotBeNil)
+			})
+		})
+
+		Convey("POST /end", func() {
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/end", strings.NewReader(`{"turn": 9}`)))
+
+			Convey("Ends the strategy", func() {
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(strategy.ended.Turn, ShouldEqual, 9)
+			})
+		})
+
+		Convey("POST /move", func() {
+			Convey("Responds with the move of the strategy", func() {
+				server.ServeHTTP(rec, newMoveRequest(500))
+
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(rec.Body.String(), ShouldEqual, `{"move":"right","shout":"hi"}`+"\n")
+			})
+
+			Convey("Hands the strategy a ready surface and flood filler", func() {
+				server.ServeHTTP(rec, newMoveRequest(500))
+
+				So(strategy.turn.State.Turn, ShouldEqual, 3)
+				So(strategy.turn.Surface.IsFilled(plane.Coord{X: 1, Y: 0}), ShouldBeTrue)
+				So(strategy.turn.FloodFiller, ShouldNotBeNil)
+			})
+
+			Convey("If the strategy takes too long", func() {
+				strategy.delay = time.Second
+
+				Convey("Responds with a move that does not run into a filled coord in time", func() {
+					start := time.Now()
+					server.ServeHTTP(rec, newMoveRequest(100))
+
+					So(time.Since(start), ShouldBeLessThan, 100*time.Millisecond)
+					So(rec.Body.String(), ShouldEqual, `{"move":"up"}`+"\n")
+				})
+			})
+
+			Convey("If the timeout buffer is larger than the timeout", func() {
+				server = New(strategy, Options{TimeoutBuffer: time.Second})
+				strategy.delay = time.Millisecond
+
+				Convey("Still gives the strategy time to choose a move", func() {
+					server.ServeHTTP(rec, newMoveRequest(500))
+
+					So(rec.Body.String(), ShouldEqual, `{"move":"right","shout":"hi"}`+"\n")
+				})
+			})
+
+			Convey("If the strategy returns an invalid direction", func() {
+				strategy.move = Move{Direction: plane.TopRight}
+
+				Convey("Responds with a move that does not run into a filled coord", func() {
+					server.ServeHTTP(rec, newMoveRequest(500))
+
+					So(rec.Body.String(), ShouldEqual, `{"move":"up"}`+"\n")
+				})
+			})
+
+			Convey("If the request body is invalid", func() {
+				server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/move", strings.NewReader(`{`)))
+
+				Convey("Responds with bad request", func() {
+					So(rec.Code, ShouldEqual, http.StatusBadRequest)
+				})
+			})
+
+			Convey("If the method is not POST", func() {
+				server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/move", nil))
+
+				Convey("Responds with method not allowed", func() {
+					So(rec.Code, ShouldEqual, http.StatusMethodNotAllowed)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,200 @@
+package battlesnake
+
+import (
+	"github.com/minitauros/go-plane"
+)
+
+// MaxHealth is the health of a snake that just ate.
+const MaxHealth = 100
+
+// Causes of the elimination of a snake, as used by the Battlesnake engine.
+const (
+	EliminatedByOutOfHealth   = "out-of-health"
+	EliminatedByOutOfBounds   = "wall-collision"
+	EliminatedBySelfCollision = "snake-self-collision"
+	EliminatedByCollision     = "snake-collision"
+	EliminatedByHeadToHead    = "head-collision"
+)
+
+// Elimination describes why a snake was eliminated.
+type Elimination struct {
+	SnakeID string
+	Cause   string
+	// By is the ID of the snake that the eliminated snake collided with, if any.
+	By string
+}
+
+// SimulateTurn returns the game state after each snake has moved in the given direction, following the standard
+// rules: snakes move, lose health, take hazard damage, eat and grow, and are then eliminated if they are out of health,
+// moved off the board, or collided with a body or a head of a snake at least as long. Snakes that have no move continue
+// in the direction they were going. Snakes without a body are left as they are. The eliminated snakes are removed from
+// the board, and returned with the reason of their elimination. Food is not spawned. The given game state is not
+// changed.
+func SimulateTurn(state *GameState, moves map[string]plane.Direction) (*GameState, []Elimination) {
+	next := state.Clone()
+	next.Turn++
+	board := &next.Board
+	surface := plane.NewSurface(board.Width, board.Height)
+	if next.Game.Ruleset.Name == RulesetWrapped {
+		surface = plane.NewWrappedSurface(board.Width, board.Height)
+	}
+
+	// Move the snakes, and take away health.
+	for i := range board.Snakes {
+		snake := &board.Snakes[i]
+		if len(snake.Body) == 0 {
+			continue
+		}
+		d, ok := moves[snake.ID]
+		if !ok {
+			d = snake.getDirection()
+		}
+		snake.Head = surface.Wrap(snake.Body[0].GetCoordInDirection(d))
+		snake.Body = append([]plane.Coord{snake.Head}, snake.Body[:len(snake.Body)-1]...)
+		snake.Health--
+	}
+
+	hazards := plane.NewSurface(board.Width, board.Height)
+	hazards.Fill(board.Hazards...)
+	food := plane.NewSurface(board.Width, board.Height)
+	food.Fill(board.Food...)
+
+	// Damage the snakes in hazards, unless they are about to eat.
+	for i := range board.Snakes {
+		snake := &board.Snakes[i]
+		if len(snake.Body) == 0 || !surface.Fits(snake.Head) {
+			continue
+		}
+		if !hazards.IsFilled(snake.Head) || food.IsFilled(snake.Head) {
+			continue
+		}
+		snake.Health -= next.Game.Ruleset.Settings.HazardDamagePerTurn
+		if snake.Health < 0 {
+			snake.Health = 0
+		}
+	}
+
+	// Feed the snakes, which makes them grow by one on the next move.
+	eaten := plane.NewSurface(board.Width, board.Height)
+	for i := range board.Snakes {
+		snake := &board.Snakes[i]
+		if len(snake.Body) == 0 || !surface.Fits(snake.Head) || !food.IsFilled(snake.Head) {
+			continue
+		}
+		snake.Health = MaxHealth
+		snake.Body = append(snake.Body, snake.Body[len(snake.Body)-1])
+		eaten.Fill(snake.Head)
+	}
+	remainingFood := make([]plane.Coord, 0, len(board.Food))
+	for _, c := range board.Food {
+		if !eaten.IsFilled(c) {
+			remainingFood = append(remainingFood, c)
+		}
+	}
+	board.Food = remainingFood
+	for i := range board.Snakes {
+		board.Snakes[i].Length = len(board.Snakes[i].Body)
+	}
+
+	eliminations := next.eliminate(surface)
+	for _, e := range eliminations {
+		if e.SnakeID == next.You.ID {
+			next.You.Health = 0
+		}
+	}
+	for _, snake := range board.Snakes {
+		if snake.ID == next.You.ID {
+			next.You = snake
+		}
+	}
+	return next, eliminations
+}
+
+// eliminate removes the snakes that are out of health, moved off the given surface, or collided, and returns why they
+// were eliminated. Collisions are checked against all snakes that are still on the surface, including the ones that
+// are eliminated by a collision themselves. Snakes without a body are kept, and are not collided with.
+func (s *GameState) eliminate(surface *plane.Surface) []Elimination {
+	var eliminations []Elimination
+	remaining := make([]Snake, 0, len(s.Board.Snakes))
+	var bodiless []Snake
+	for _, snake := range s.Board.Snakes {
+		switch {
+		case len(snake.Body) == 0:
+			bodiless = append(bodiless, snake)
+		case snake.Health <= 0:
+			eliminations = append(eliminations, Elimination{SnakeID: snake.ID, Cause: EliminatedByOutOfHealth})
+		case !surface.Fits(snake.Head):
+			eliminations = append(eliminations, Elimination{SnakeID: snake.ID, Cause: EliminatedByOutOfBounds})
+		default:
+			remaining = append(remaining, snake)
+		}
+	}
+
+	// bodies holds for each coord that is part of a body, apart from the heads, the snake it belongs to.
+	bodies := make(map[plane.Coord]string)
+	for _, snake := range remaining {
+		for _, c := range snake.Body[1:] {
+			bodies[c] = snake.ID
+		}
+	}
+
+	survivors := make([]Snake, 0, len(remaining))
+	for _, snake := range remaining {
+		if id, ok := bodies[snake.Head]; ok {
+			cause := EliminatedByCollision
+			if id == snake.ID {
+				cause = EliminatedBySelfCollision
+			}
+			eliminations = append(eliminations, Elimination{SnakeID: snake.ID, Cause: cause, By: id})
+			continue
+		}
+		var lostHeadToHead bool
+		for _, other := range remaining {
+			if other.ID != snake.ID && other.Head == snake.Head && len(other.Body) >= len(snake.Body) {
+				eliminations = append(eliminations, Elimination{
+					SnakeID: snake.ID,
+					Cause:   EliminatedByHeadToHead,
+					By:      other.ID,
+				})
+				lostHeadToHead = true
+				break
+			}
+		}
+		if !lostHeadToHead {
+			survivors = append(survivors, snake)
+		}
+	}
+	s.Board.Snakes = append(survivors, bodiless...)
+	return eliminations
+}
+
+// getDirection returns the direction in which the snake was going, or up if that is not known.
+func (s Snake) getDirection() plane.Direction {
+	if len(s.Body) < 2 || s.Body[0] == s.Body[1] {
+		return plane.Top
+	}
+	d := s.Body[1].GetDirectionsTo(s.Body[0])[0]
+	if s.Body[1].GetCoordInDirection(d) != s.Body[0] {
+		// The snake moved across the edge of a wrapped board.
+		return d.Opposite()
+	}
+	return d
+}
+
+// Clone returns a deep copy of the game state.
+func (s *GameState) Clone() *GameState {
+	clone := *s
+	clone.Board.Food = append([]plane.Coord(nil), s.Board.Food...)
+	clone.Board.Hazards = append([]plane.Coord(nil), s.Board.Hazards...)
+	clone.Board.Snakes = make([]Snake, len(s.Board.Snakes))
+	for i, snake := range s.Board.Snakes {
+		clone.Board.Snakes[i] = snake.clone()
+	}
+	clone.You = s.You.clone()
+	return &clone
+}
+
+func (s Snake) clone() Snake {
+	s.Body = append([]plane.Coord(nil), s.Body...)
+	return s
+}
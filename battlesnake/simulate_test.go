@@ -0,0 +1,208 @@
+package battlesnake_test
+
+import (
+	"testing"
+
+	"github.com/minitauros/go-plane"
+	"github.com/minitauros/go-plane/battlesnake"
+	"github.com/minitauros/go-plane/battlesnake/battlesnaketest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_SimulateTurn(t *testing.T) {
+	Convey("SimulateTurn()", t, func() {
+		you := battlesnaketest.NewSnake(
+			"you",
+			50,
+			plane.Coord{X: 1, Y: 1},
+			plane.Coord{X: 1, Y: 0},
+			plane.Coord{X: 0, Y: 0},
+		)
+		state := battlesnaketest.NewGameState(you)
+
+		Convey("Moves the snakes and takes away health", func() {
+			next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+			So(eliminations, ShouldBeEmpty)
+			So(next.Turn, ShouldEqual, 2)
+			So(next.You.Head, ShouldResemble, plane.Coord{X: 2, Y: 1})
+			So(next.You.Body, ShouldResemble, []plane.Coord{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}})
+			So(next.You.Health, ShouldEqual, 49)
+			So(next.Board.Snakes[0], ShouldResemble, next.You)
+		})
+
+		Convey("Does not change the given state", func() {
+			battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+			So(state.Turn, ShouldEqual, 1)
+			So(state.Board.Snakes[0], ShouldResemble, you)
+		})
+
+		Convey("Continues in the same direction if there is no move", func() {
+			next, _ := battlesnake.SimulateTurn(state, nil)
+
+			So(next.You.Head, ShouldResemble, plane.Coord{X: 1, Y: 2})
+		})
+
+		Convey("Grows and restores health when eating", func() {
+			state.Board.Food = []plane.Coord{{X: 2, Y: 1}, {X: 4, Y: 4}}
+
+			next, _ := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+			So(next.You.Health, ShouldEqual, battlesnake.MaxHealth)
+			So(next.You.Length, ShouldEqual, 4)
+			So(next.You.Body, ShouldResemble, []plane.Coord{{X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: 0}})
+			So(next.Board.Food, ShouldResemble, []plane.Coord{{X: 4, Y: 4}})
+		})
+
+		Convey("Applies hazard damage", func() {
+			state.Game.Ruleset.Settings.HazardDamagePerTurn = 14
+			state.Board.Hazards = []plane.Coord{{X: 2, Y: 1}}
+
+			next, _ := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+			So(next.You.Health, ShouldEqual, 35)
+
+			Convey("Unless the snake eats in the hazard", func() {
+				state.Board.Food = []plane.Coord{{X: 2, Y: 1}}
+
+				next, _ := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+				So(next.You.Health, ShouldEqual, battlesnake.MaxHealth)
+			})
+		})
+
+		Convey("Eliminates snakes that are out of health", func() {
+			state.Board.Snakes[0].Health = 1
+
+			next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Right})
+
+			So(eliminations, ShouldResemble, []battlesnake.Elimination{
+				{SnakeID: "you", Cause: battlesnake.EliminatedByOutOfHealth},
+			})
+			So(next.Board.Snakes, ShouldBeEmpty)
+			So(next.You.Health, ShouldEqual, 0)
+		})
+
+		Convey("Eliminates snakes that move off the board", func() {
+			next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Left})
+			next, eliminations = battlesnake.SimulateTurn(next, map[string]plane.Direction{"you": plane.Left})
+
+			So(eliminations, ShouldResemble, []battlesnake.Elimination{
+				{SnakeID: "you", Cause: battlesnake.EliminatedByOutOfBounds},
+			})
+			So(next.Board.Snakes, ShouldBeEmpty)
+		})
+
+		Convey("Wraps snakes around the edges in the wrapped game mode", func() {
+			state.Game.Ruleset.Name = battlesnake.RulesetWrapped
+
+			next, _ := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Left})
+			next, eliminations := battlesnake.SimulateTurn(next, map[string]plane.Direction{"you": plane.Left})
+
+			So(eliminations, ShouldBeEmpty)
+			So(next.You.Head, ShouldResemble, plane.Coord{X: 4, Y: 1})
+
+			Convey("And keeps going in the same direction", func() {
+				next, _ = battlesnake.SimulateTurn(next, nil)
+
+				So(next.You.Head, ShouldResemble, plane.Coord{X: 3, Y: 1})
+			})
+		})
+
+		Convey("Eliminates snakes that move into themselves", func() {
+			_, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Bot})
+
+			So(eliminations, ShouldResemble, []battlesnake.Elimination{
+				{SnakeID: "you", Cause: battlesnake.EliminatedBySelfCollision, By: "you"},
+			})
+		})
+
+		Convey("Does not eliminate snakes that move onto the coord their tail moves away from", func() {
+			// A snake of four, curled up in a square.
+			state := battlesnaketest.NewGameState(battlesnaketest.NewSnake("you", 50,
+				plane.Coord{X: 0, Y: 1}, plane.Coord{X: 1, Y: 1}, plane.Coord{X: 1, Y: 0}, plane.Coord{X: 0, Y: 0},
+			))
+
+			_, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{"you": plane.Bot})
+
+			So(eliminations, ShouldBeEmpty)
+		})
+
+		Convey("Leaves snakes without a body as they are", func() {
+			state.Game.Ruleset.Settings.HazardDamagePerTurn = 14
+			state.Board.Food = []plane.Coord{{X: 1, Y: 2}}
+			state.Board.Hazards = []plane.Coord{{X: 3, Y: 3}}
+			onFood := battlesnake.Snake{ID: "on-food", Health: 50, Head: plane.Coord{X: 1, Y: 2}}
+			inHazard := battlesnake.Snake{ID: "in-hazard", Health: 50, Head: plane.Coord{X: 3, Y: 3}}
+			state.Board.Snakes = append(state.Board.Snakes, onFood, inHazard)
+
+			next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{
+				"you":       plane.Top,
+				"on-food":   plane.Top,
+				"in-hazard": plane.Top,
+			})
+
+			So(eliminations, ShouldBeEmpty)
+			So(next.Board.Snakes, ShouldHaveLength, 3)
+			So(next.You.Length, ShouldEqual, 4)
+			So(next.Board.Snakes[1], ShouldResemble, onFood)
+			So(next.Board.Snakes[2], ShouldResemble, inHazard)
+		})
+
+		Convey("With other snakes", func() {
+			other := battlesnaketest.NewSnake(
+				"other",
+				50,
+				plane.Coord{X: 2, Y: 2},
+				plane.Coord{X: 3, Y: 2},
+				plane.Coord{X: 4, Y: 2},
+			)
+			state.Board.Snakes = append(state.Board.Snakes, other)
+
+			Convey("Eliminates snakes that move into the body of another snake", func() {
+				state.Board.Snakes[1] = battlesnaketest.NewSnake(
+					"other",
+					50,
+					plane.Coord{X: 2, Y: 2},
+					plane.Coord{X: 1, Y: 2},
+					plane.Coord{X: 0, Y: 2},
+				)
+
+				next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{
+					"you":   plane.Top,
+					"other": plane.Top,
+				})
+
+				So(eliminations, ShouldResemble, []battlesnake.Elimination{
+					{SnakeID: "you", Cause: battlesnake.EliminatedByCollision, By: "other"},
+				})
+				So(next.Board.Snakes, ShouldHaveLength, 1)
+				So(next.Board.Snakes[0].ID, ShouldEqual, "other")
+			})
+
+			Convey("Eliminates the shorter snake in a head to head collision", func() {
+				state.Board.Snakes[1].Body = append(state.Board.Snakes[1].Body, plane.Coord{X: 4, Y: 3})
+
+				_, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{
+					"you":   plane.Top,
+					"other": plane.Left,
+				})
+
+				So(eliminations, ShouldResemble, []battlesnake.Elimination{
+					{SnakeID: "you", Cause: battlesnake.EliminatedByHeadToHead, By: "other"},
+				})
+			})
+
+			Convey("Eliminates both snakes in a head to head collision of equal length", func() {
+				next, eliminations := battlesnake.SimulateTurn(state, map[string]plane.Direction{
+					"you":   plane.Top,
+					"other": plane.Left,
+				})
+
+				So(eliminations, ShouldHaveLength, 2)
+				So(next.Board.Snakes, ShouldBeEmpty)
+			})
+		})
+	})
+}
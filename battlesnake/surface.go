@@ -0,0 +1,75 @@
+package battlesnake
+
+import (
+	"github.com/minitauros/go-plane"
+)
+
+// SurfaceOptions define which coords of the board are filled on the surface that NewSurface returns.
+// The zero value fills all snake bodies, and makes hazards more expensive to move onto.
+type SurfaceOptions struct {
+	// FreeMovingTails leaves the tails of snakes unfilled if they will move away next turn.
+	FreeMovingTails bool
+	// FreeBodiesOverTime fills each segment of the snake bodies until the turn at which the tail will have moved away
+	// from it (see plane.Surface.FillUntil), assuming the snakes do not eat. Tails that will move away next turn are
+	// filled until the next turn, unless FreeMovingTails is set.
+	FreeBodiesOverTime bool
+	// FillHazards fills hazards, instead of making them more expensive to move onto.
+	FillHazards bool
+	// HazardCost is the cost of moving onto a hazard, if hazards are not filled. If it is 0, the hazard damage per turn
+	// of the ruleset plus 1 is used.
+	HazardCost int
+	// FillFood fills food, for example to avoid eating.
+	FillFood bool
+}
+
+// NewSurface returns a surface of the size of the board of the given game state, on which the snake bodies, hazards
+// and food are placed according to the given options. In the wrapped game mode, the surface is wrapped.
+// Regardless of the options, the cells of the surface tell what is where (see plane.Surface.SetCell): the bodies of the
+// snakes are owned by the index of the snake in Board.Snakes. A cell has a single kind, so bodies take precedence over
+// hazards, and hazards over food: food in a hazard is a hazard cell, as moving onto it still costs health. Board.Food
+// still tells where all food is.
+func NewSurface(state *GameState, opts SurfaceOptions) *plane.Surface {
+	var s *plane.Surface
+	if state.Game.Ruleset.Name == RulesetWrapped {
+		s = plane.NewWrappedSurface(state.Board.Width, state.Board.Height)
+	} else {
+		s = plane.NewSurface(state.Board.Width, state.Board.Height)
+	}
+
+	s.SetCell(plane.Cell{Kind: plane.CellFood}, state.Board.Food...)
+	s.SetCell(plane.Cell{Kind: plane.CellHazard}, state.Board.Hazards...)
+	for i, snake := range state.Board.Snakes {
+		s.SetCell(plane.Cell{Kind: plane.CellBody, Owner: i}, snake.Body...)
+	}
+
+	if opts.FillHazards {
+		s.Fill(state.Board.Hazards...)
+	} else {
+		hazardCost := opts.HazardCost
+		if hazardCost == 0 {
+			hazardCost = state.Game.Ruleset.Settings.HazardDamagePerTurn + 1
+		}
+		s.SetCost(hazardCost, state.Board.Hazards...)
+	}
+
+	if opts.FillFood {
+		s.Fill(state.Board.Food...)
+	}
+
+	for _, snake := range state.Board.Snakes {
+		body := snake.Body
+		if opts.FreeMovingTails && snake.TailWillMove() && len(body) > 0 {
+			body = body[:len(body)-1]
+		}
+		if !opts.FreeBodiesOverTime {
+			s.Fill(body...)
+			continue
+		}
+		for i, c := range body {
+			// The last segment is free after one turn, the one before it after two, and so on. The tail of a snake
+			// that just ate is in the body twice, and thus stays filled for an extra turn.
+			s.FillUntil(len(snake.Body)-i, c)
+		}
+	}
+	return s
+}
@@ -0,0 +1,118 @@
+package battlesnake
+
+import (
+	"testing"
+
+	"github.com/minitauros/go-plane"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_NewSurface(t *testing.T) {
+	Convey("NewSurface()", t, func() {
+		state, err := ParseGameState([]byte(moveRequest))
+		So(err, ShouldBeNil)
+
+		Convey("With the default options", func() {
+			s := NewSurface(state, SurfaceOptions{})
+
+			Convey("Fills the snake bodies", func() {
+				So(s.GetFilled(), ShouldHaveLength, 7)
+				for _, snake := range state.Board.Snakes {
+					for _, c := range snake.Body {
+						So(s.IsFilled(c), ShouldBeTrue)
+					}
+				}
+			})
+
+			Convey("Makes hazards more expensive to move onto", func() {
+				So(s.IsFilled(plane.Coord{X: 3, Y: 2}), ShouldBeFalse)
+				So(s.GetCost(plane.Coord{X: 3, Y: 2}), ShouldEqual, 15)
+			})
+
+			Convey("Does not fill food", func() {
+				So(s.IsFilled(plane.Coord{X: 5, Y: 5}), ShouldBeFalse)
+			})
+
+			Convey("Is not wrapped", func() {
+				So(s.IsWrapped(), ShouldBeFalse)
+			})
+		})
+
+		Convey("With free moving tails", func() {
+			s := NewSurface(state, SurfaceOptions{FreeMovingTails: true})
+
+			Convey("Does not fill the tails that will move", func() {
+				So(s.IsFilled(plane.Coord{X: 2, Y: 0}), ShouldBeFalse)
+			})
+
+			Convey("Fills the tails of snakes that just ate", func() {
+				So(s.IsFilled(plane.Coord{X: 6, Y: 2}), ShouldBeTrue)
+			})
+		})
+
+		Convey("With bodies that free up over time", func() {
+			s := NewSurface(state, SurfaceOptions{FreeBodiesOverTime: true})
+
+			Convey("Fills each segment until the tail has moved away from it", func() {
+				So(s.IsFilledAt(plane.Coord{X: 2, Y: 0}, 0), ShouldBeTrue)
+				So(s.IsFilledAt(plane.Coord{X: 2, Y: 0}, 1), ShouldBeFalse)
+				So(s.IsFilledAt(plane.Coord{X: 1, Y: 0}, 1), ShouldBeTrue)
+				So(s.IsFilledAt(plane.Coord{X: 1, Y: 0}, 2), ShouldBeFalse)
+				So(s.IsFilledAt(plane.Coord{X: 5, Y: 4}, 4), ShouldBeTrue)
+				So(s.IsFilledAt(plane.Coord{X: 5, Y: 4}, 5), ShouldBeFalse)
+			})
+
+			Convey("Keeps the tail of a snake that just ate filled for an extra turn", func() {
+				So(s.IsFilledAt(plane.Coord{X: 6, Y: 2}, 1), ShouldBeTrue)
+				So(s.IsFilledAt(plane.Coord{X: 6, Y: 2}, 2), ShouldBeFalse)
+			})
+
+			Convey("Still treats the bodies as filled", func() {
+				So(s.GetFilled(), ShouldHaveLength, 7)
+			})
+		})
+
+		Convey("With filled hazards and food", func() {
+			s := NewSurface(state, SurfaceOptions{FillHazards: true, FillFood: true})
+
+			So(s.IsFilled(plane.Coord{X: 3, Y: 2}), ShouldBeTrue)
+			So(s.GetCost(plane.Coord{X: 3, Y: 2}), ShouldEqual, 1)
+			for _, c := range state.Board.Food {
+				So(s.IsFilled(c), ShouldBeTrue)
+			}
+		})
+
+		Convey("With a custom hazard cost", func() {
+			s := NewSurface(state, SurfaceOptions{HazardCost: 3})
+
+			So(s.GetCost(plane.Coord{X: 3, Y: 2}), ShouldEqual, 3)
+		})
+
+		Convey("Tells what is where, regardless of the options", func() {
+			s := NewSurface(state, SurfaceOptions{FreeMovingTails: true, FillHazards: true})
+
+			So(s.CellsOfOwner(plane.CellBody, 0), ShouldResemble, plane.Coords{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}})
+			So(s.CellsOfOwner(plane.CellBody, 1), ShouldHaveLength, 4)
+			So(s.GetCell(plane.Coord{X: 5, Y: 5}), ShouldResemble, plane.Cell{Kind: plane.CellFood})
+			So(s.GetCell(plane.Coord{X: 3, Y: 2}), ShouldResemble, plane.Cell{Kind: plane.CellHazard})
+			So(s.GetCell(plane.Coord{X: 4, Y: 4}), ShouldResemble, plane.Cell{Kind: plane.CellEmpty})
+		})
+
+		Convey("Keeps hazards that food is in", func() {
+			state.Board.Food = append(state.Board.Food, plane.Coord{X: 3, Y: 2})
+
+			s := NewSurface(state, SurfaceOptions{})
+
+			So(s.GetCell(plane.Coord{X: 3, Y: 2}), ShouldResemble, plane.Cell{Kind: plane.CellHazard})
+			So(s.GetCost(plane.Coord{X: 3, Y: 2}), ShouldEqual, 15)
+		})
+
+		Convey("In the wrapped game mode", func() {
+			state.Game.Ruleset.Name = RulesetWrapped
+
+			Convey("Returns a wrapped surface", func() {
+				So(NewSurface(state, SurfaceOptions{}).IsWrapped(), ShouldBeTrue)
+			})
+		})
+	})
+}
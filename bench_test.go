@@ -19,3 +19,53 @@ func Benchmark_fillAndCount(b *testing.B) {
 		ff.flood(Coord{0, 0}, Coord{10, 10}, true)
 	}
 }
+
+func Benchmark_CountSteps_11x11(b *testing.B) {
+	benchmarkCountSteps(b, 11)
+}
+
+func Benchmark_CountSteps_19x19(b *testing.B) {
+	benchmarkCountSteps(b, 19)
+}
+
+func Benchmark_CountSteps_100x100(b *testing.B) {
+	benchmarkCountSteps(b, 100)
+}
+
+func benchmarkCountSteps(b *testing.B, size int) {
+	for i := 0; i < b.N; i++ {
+		s := NewSurface(size, size)
+		ff := NewFloodFiller(s)
+		ff.CountSteps(Coord{0, 0}, Coord{size - 1, size - 1})
+	}
+}
+
+func Benchmark_FindPath_19x19(b *testing.B) {
+	s := NewSurface(19, 19)
+	pf := NewPathFinder(s)
+	for i := 0; i < b.N; i++ {
+		pf.FindPath(Coord{0, 0}, Coord{18, 18})
+	}
+}
+
+func Benchmark_GetTerritories_19x19(b *testing.B) {
+	s := NewSurface(19, 19)
+	ff := NewFloodFiller(s)
+	for i := 0; i < b.N; i++ {
+		ff.GetTerritories(Coord{0, 0}, Coord{18, 18}, Coord{9, 9}, Coord{0, 18})
+	}
+}
+
+func Benchmark_Coords_Equals_1000(b *testing.B) {
+	s := NewSurface(100, 10)
+	s.Invert()
+	filled := s.GetFilled()
+	reversed := make(Coords, len(filled))
+	for i, c := range filled {
+		reversed[len(filled)-i-1] = c
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filled.Equals(reversed)
+	}
+}
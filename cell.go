@@ -0,0 +1,88 @@
+package plane
+
+// CellKind is the kind of thing that is at a coord.
+type CellKind uint8
+
+const (
+	// CellEmpty is the kind of coords on which nothing is.
+	CellEmpty CellKind = iota
+	// CellWall is the kind of coords that are walls. Coords that do not fit on the surface are walls too.
+	CellWall
+	// CellBody is the kind of coords that are part of the body of a snake, of which the owner is the snake.
+	CellBody
+	// CellFood is the kind of coords on which food is.
+	CellFood
+	// CellHazard is the kind of coords that are hazards.
+	CellHazard
+)
+
+// String satisfies stringer.
+func (k CellKind) String() string {
+	switch k {
+	case CellEmpty:
+		return "empty"
+	case CellWall:
+		return "wall"
+	case CellBody:
+		return "body"
+	case CellFood:
+		return "food"
+	case CellHazard:
+		return "hazard"
+	}
+	return "unknown"
+}
+
+// Cell describes what is at a coord: its kind, and who owns it, for example the index of the snake of which the coord
+// is part of the body.
+type Cell struct {
+	Kind  CellKind
+	Owner int
+}
+
+// SetCell sets what is at the given coords. A coord holds a single cell, so the cell replaces any cell that was at the
+// coord before. Cells are independent of whether coords are filled: filling or removing a coord does not change its
+// cell, and setting a cell does not fill the coord. See FloodFiller.SetBlockingKinds to make floods treat cells of
+// given kinds as filled.
+func (s *Surface) SetCell(cell Cell, coords ...Coord) {
+	for _, coord := range coords {
+		coord = s.Wrap(coord)
+		if !s.Fits(coord) {
+			continue
+		}
+		s.cells[s.index(coord)].kind = cell.Kind
+		s.cells[s.index(coord)].owner = cell.Owner
+	}
+}
+
+// GetCell returns what is at the given coord. Coords that do not fit on the surface are walls.
+func (s *Surface) GetCell(coord Coord) Cell {
+	coord = s.Wrap(coord)
+	if !s.Fits(coord) {
+		return Cell{Kind: CellWall}
+	}
+	v := s.cells[s.index(coord)]
+	return Cell{Kind: v.kind, Owner: v.owner}
+}
+
+// CellsOfKind returns the coords that hold a cell of the given kind.
+func (s *Surface) CellsOfKind(kind CellKind) Coords {
+	var coords Coords
+	for i, v := range s.cells {
+		if v.kind == kind {
+			coords = append(coords, s.coordAt(i))
+		}
+	}
+	return coords
+}
+
+// CellsOfOwner returns the coords that hold a cell of the given kind and owner, for example the body of a given snake.
+func (s *Surface) CellsOfOwner(kind CellKind, owner int) Coords {
+	var coords Coords
+	for i, v := range s.cells {
+		if v.kind == kind && v.owner == owner {
+			coords = append(coords, s.coordAt(i))
+		}
+	}
+	return coords
+}
@@ -0,0 +1,94 @@
+package plane
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Surface_Cells(t *testing.T) {
+	Convey("Surface cells", t, func() {
+		s := NewSurface(3, 3)
+
+		Convey("Are empty by default", func() {
+			So(s.GetCell(Coord{1, 1}), ShouldResemble, Cell{})
+			So(s.CellsOfKind(CellEmpty), ShouldHaveLength, 9)
+		})
+
+		Convey("Can be set and queried", func() {
+			s.SetCell(Cell{Kind: CellBody, Owner: 2}, Coord{0, 0}, Coord{1, 0})
+			s.SetCell(Cell{Kind: CellBody, Owner: 3}, Coord{2, 2})
+			s.SetCell(Cell{Kind: CellFood}, Coord{1, 1})
+
+			So(s.GetCell(Coord{1, 0}), ShouldResemble, Cell{Kind: CellBody, Owner: 2})
+			So(s.CellsOfKind(CellBody), ShouldResemble, Coords{{0, 0}, {1, 0}, {2, 2}})
+			So(s.CellsOfKind(CellFood), ShouldResemble, Coords{{1, 1}})
+			So(s.CellsOfKind(CellHazard), ShouldBeEmpty)
+			So(s.CellsOfOwner(CellBody, 2), ShouldResemble, Coords{{0, 0}, {1, 0}})
+			So(s.CellsOfOwner(CellBody, 3), ShouldResemble, Coords{{2, 2}})
+		})
+
+		Convey("Replace the cell that was at the coord before", func() {
+			s.SetCell(Cell{Kind: CellHazard}, Coord{1, 1})
+			s.SetCell(Cell{Kind: CellBody, Owner: 1}, Coord{1, 1})
+
+			So(s.GetCell(Coord{1, 1}), ShouldResemble, Cell{Kind: CellBody, Owner: 1})
+			So(s.CellsOfKind(CellHazard), ShouldBeEmpty)
+		})
+
+		Convey("Do not fill coords, and are kept when filling and removing coords", func() {
+			s.SetCell(Cell{Kind: CellWall}, Coord{1, 1})
+			So(s.IsFilled(Coord{1, 1}), ShouldBeFalse)
+
+			s.Fill(Coord{1, 1})
+			So(s.GetCell(Coord{1, 1}), ShouldResemble, Cell{Kind: CellWall})
+			s.Remove(Coord{1, 1})
+			So(s.GetCell(Coord{1, 1}), ShouldResemble, Cell{Kind: CellWall})
+			s.FillUntil(2, Coord{1, 1})
+			So(s.GetCell(Coord{1, 1}), ShouldResemble, Cell{Kind: CellWall})
+		})
+
+		Convey("Are cloned", func() {
+			s.SetCell(Cell{Kind: CellFood}, Coord{1, 1})
+
+			So(s.Clone().GetCell(Coord{1, 1}), ShouldResemble, Cell{Kind: CellFood})
+		})
+
+		Convey("Are walls outside of the surface", func() {
+			So(s.GetCell(Coord{-1, 0}), ShouldResemble, Cell{Kind: CellWall})
+			s.SetCell(Cell{Kind: CellFood}, Coord{3, 0})
+			So(s.CellsOfKind(CellFood), ShouldBeEmpty)
+		})
+
+		Convey("Wrap on wrapped surfaces", func() {
+			wrapped := NewWrappedSurface(3, 3)
+			wrapped.SetCell(Cell{Kind: CellFood}, Coord{-1, 0})
+
+			So(wrapped.GetCell(Coord{2, 0}), ShouldResemble, Cell{Kind: CellFood})
+			So(wrapped.GetCell(Coord{5, 3}), ShouldResemble, Cell{Kind: CellFood})
+		})
+	})
+}
+
+func Test_CellKind_String(t *testing.T) {
+	Convey("CellKind.String()", t, func() {
+		testCases := []struct {
+			kind     CellKind
+			expected string
+		}{
+			{CellEmpty, "empty"},
+			{CellWall, "wall"},
+			{CellBody, "body"},
+			{CellFood, "food"},
+			{CellHazard, "hazard"},
+			{CellKind(100), "unknown"},
+		}
+
+		for i, tc := range testCases {
+			Convey(fmt.Sprintf("%d: %s", i, tc.expected), func() {
+				So(tc.kind.String(), ShouldEqual, tc.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package plane
+
+import (
+	"sort"
+)
+
+// Chokepoint is an unfilled coord that, if it were filled, would split the region it is part of into multiple
+// regions, such as the entrance of a corridor.
+type Chokepoint struct {
+	Coord Coord
+	// RegionSizes are the sizes of the regions that the region would be split into, largest first.
+	RegionSizes []int
+}
+
+// Bridge is a step between two unfilled coords that is the only connection between the two parts of the region they
+// are part of. If the step could not be taken, the region would be split in two.
+type Bridge struct {
+	From Coord
+	To   Coord
+	// FromSize is the number of coords on the side of From.
+	FromSize int
+	// ToSize is the number of coords on the side of To.
+	ToSize int
+}
+
+// GetChokepoints returns all unfilled coords that would split the region they are part of if they were filled.
+// It takes into account the connectivity of the surface and whether it is wrapped.
+func (s *Surface) GetChokepoints() []Chokepoint {
+	chokepoints, _ := s.findCuts()
+	return chokepoints
+}
+
+// GetBridges returns all steps between unfilled coords that are the only connection between two parts of a region.
+// It takes into account the connectivity of the surface and whether it is wrapped.
+func (s *Surface) GetBridges() []Bridge {
+	_, bridges := s.findCuts()
+	return bridges
+}
+
+// cutFrame is a coord that is being visited by findCuts.
+type cutFrame struct {
+	i      int
+	parent int
+	// neighbours are the indexes of the unfilled coords around the coord.
+	neighbours    []int
+	next          int
+	skippedParent bool
+}
+
+// findCuts finds the chokepoints and bridges of the surface using Tarjan's algorithm. It walks each region
+// depth-first, keeping track of the earliest visited coord that can be reached from each coord without going back the
+// way it came. If that coord was visited after the coord's parent, the parent is the only way into the coord.
+// The walk keeps its own stack, so that large surfaces do not need deep recursion.
+func (s *Surface) findCuts() ([]Chokepoint, []Bridge) {
+	n := s.TotalSurface()
+	// visitedAt holds for each coord when it was visited, starting at 1, so that 0 means not visited.
+	visitedAt := make([]int, n)
+	// earliest holds for each coord the earliest visitedAt that can be reached from it.
+	earliest := make([]int, n)
+	// subtreeSizes holds for each coord the number of coords that were visited through it, including itself.
+	subtreeSizes := make([]int, n)
+	// cutOff holds for each coord the sizes of the parts that would be cut off if it were filled.
+	cutOff := make([][]int, n)
+	regionSizes := make([]int, n)
+	// bridgeEnds holds the indexes of the coords at the far end of each bridge, seen from the start of the walk.
+	var bridgeEnds []int
+	parents := make([]int, n)
+	var numVisited int
+	// regionMembers holds the indexes of the coords visited in the region that is currently walked.
+	var regionMembers []int
+
+	visit := func(i, parent int) *cutFrame {
+		numVisited++
+		visitedAt[i] = numVisited
+		earliest[i] = numVisited
+		subtreeSizes[i] = 1
+		parents[i] = parent
+		regionMembers = append(regionMembers, i)
+		f := &cutFrame{i: i, parent: parent}
+		for _, c := range s.GetCoordsAround(s.coordAt(i)) {
+			if s.IsFilled(c) {
+				continue
+			}
+			if j := s.index(s.Wrap(c)); j != i {
+				f.neighbours = append(f.neighbours, j)
+			}
+		}
+		return f
+	}
+
+	for root := 0; root < n; root++ {
+		if s.cells[root].isFilled || visitedAt[root] != 0 {
+			continue
+		}
+		regionMembers = regionMembers[:0]
+		stack := []*cutFrame{visit(root, -1)}
+		for len(stack) > 0 {
+			f := stack[len(stack)-1]
+			if f.next < len(f.neighbours) {
+				j := f.neighbours[f.next]
+				f.next++
+				if j == f.parent && !f.skippedParent {
+					// Don't go back the way we came, but do count any other connections to the parent.
+					f.skippedParent = true
+					continue
+				}
+				if visitedAt[j] == 0 {
+					stack = append(stack, visit(j, f.i))
+					continue
+				}
+				earliest[f.i] = minInt(earliest[f.i], visitedAt[j])
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if f.parent == -1 {
+				continue
+			}
+			subtreeSizes[f.parent] += subtreeSizes[f.i]
+			earliest[f.parent] = minInt(earliest[f.parent], earliest[f.i])
+			if earliest[f.i] >= visitedAt[f.parent] {
+				cutOff[f.parent] = append(cutOff[f.parent], subtreeSizes[f.i])
+			}
+			if earliest[f.i] > visitedAt[f.parent] {
+				bridgeEnds = append(bridgeEnds, f.i)
+			}
+		}
+		for _, i := range regionMembers {
+			regionSizes[i] = subtreeSizes[root]
+		}
+	}
+
+	var chokepoints []Chokepoint
+	for i, sizes := range cutOff {
+		var numCutOff int
+		for _, size := range sizes {
+			numCutOff += size
+		}
+		// The coords that are not cut off stay connected to the parent, if there is one.
+		if rest := regionSizes[i] - 1 - numCutOff; rest > 0 {
+			sizes = append(sizes, rest)
+		}
+		if len(sizes) < 2 {
+			continue
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+		chokepoints = append(chokepoints, Chokepoint{
+			Coord:       s.coordAt(i),
+			RegionSizes: sizes,
+		})
+	}
+
+	bridges := make([]Bridge, 0, len(bridgeEnds))
+	for _, i := range bridgeEnds {
+		bridges = append(bridges, Bridge{
+			From:     s.coordAt(parents[i]),
+			To:       s.coordAt(i),
+			FromSize: regionSizes[i] - subtreeSizes[i],
+			ToSize:   subtreeSizes[i],
+		})
+	}
+	return chokepoints, bridges
+}
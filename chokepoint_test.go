@@ -0,0 +1,169 @@
+package plane
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Surface_GetChokepoints(t *testing.T) {
+	Convey("Surface.GetChokepoints()", t, func() {
+		Convey("Without chokepoints", func() {
+			Convey("Returns nothing", func() {
+				So(NewSurface(3, 3).GetChokepoints(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("With a corridor", func() {
+			// . . x . . .
+			// . . . . . .
+			// . . x . . .
+			s := NewSurface(6, 3)
+			s.fillRows([][]int{
+				{0, 0, 1, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 1, 0, 0, 0},
+			})
+
+			Convey("Returns the corridor and its entrances with the sizes of the regions they separate", func() {
+				chokepoints := s.GetChokepoints()
+
+				So(chokepoints, ShouldResemble, []Chokepoint{
+					{Coord: Coord{1, 1}, RegionSizes: []int{10, 5}},
+					{Coord: Coord{2, 1}, RegionSizes: []int{9, 6}},
+					{Coord: Coord{3, 1}, RegionSizes: []int{8, 7}},
+				})
+			})
+		})
+
+		Convey("With a dead end", func() {
+			// . . . .
+			// x x x .
+			// . . . .
+			s := NewSurface(4, 3)
+			s.fillRows([][]int{
+				{0, 0, 0, 0},
+				{1, 1, 1, 0},
+				{0, 0, 0, 0},
+			})
+
+			Convey("Returns each coord of the path that leads into the dead ends", func() {
+				chokepoints := s.GetChokepoints()
+
+				So(chokepoints, ShouldHaveLength, 7)
+				So(chokepoints[0], ShouldResemble, Chokepoint{Coord: Coord{1, 0}, RegionSizes: []int{7, 1}})
+				So(chokepoints[2], ShouldResemble, Chokepoint{Coord: Coord{3, 0}, RegionSizes: []int{5, 3}})
+				So(chokepoints[3], ShouldResemble, Chokepoint{Coord: Coord{3, 1}, RegionSizes: []int{4, 4}})
+			})
+		})
+
+		Convey("If the chokepoint is where the walk starts", func() {
+			// x . x
+			// . . .
+			// x . x
+			s := NewSurface(3, 3)
+			s.fillRows([][]int{
+				{1, 0, 1},
+				{0, 0, 0},
+				{1, 0, 1},
+			})
+
+			Convey("Returns all regions it separates", func() {
+				chokepoints := s.GetChokepoints()
+
+				So(chokepoints, ShouldResemble, []Chokepoint{
+					{Coord: Coord{1, 1}, RegionSizes: []int{1, 1, 1, 1}},
+				})
+			})
+		})
+
+		Convey("Returns the same as filling each coord and counting regions", func() {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				s := NewSurface(7, 7)
+				if i%2 == 1 {
+					s = NewWrappedSurface(7, 7)
+				}
+				for j := 0; j < 15; j++ {
+					s.Fill(Coord{r.Intn(7), r.Intn(7)})
+				}
+
+				var expected []Coord
+				for _, region := range s.GetRegions().All() {
+					for _, c := range region.Coords {
+						clone := s.Clone()
+						clone.Fill(c)
+						if clone.GetRegions().Count() > s.GetRegions().Count() {
+							expected = append(expected, c)
+						}
+					}
+				}
+
+				var actual []Coord
+				for _, chokepoint := range s.GetChokepoints() {
+					actual = append(actual, chokepoint.Coord)
+				}
+				So(Coords(actual).Equals(expected), ShouldBeTrue)
+			}
+		})
+
+		Convey("If wrapped", func() {
+			// . . x . . .
+			// . . . . . .
+			// . . x . . .
+			s := NewWrappedSurface(6, 3)
+			s.fillRows([][]int{
+				{0, 0, 1, 0, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 1, 0, 0, 0},
+			})
+
+			Convey("The way around the edges is not a chokepoint", func() {
+				So(s.GetChokepoints(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func Test_Surface_GetBridges(t *testing.T) {
+	Convey("Surface.GetBridges()", t, func() {
+		Convey("Without bridges", func() {
+			Convey("Returns nothing", func() {
+				So(NewSurface(3, 3).GetBridges(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("With a corridor", func() {
+			// . . x x . .
+			// . . . . . .
+			// . . x x . .
+			s := NewSurface(6, 3)
+			s.fillRows([][]int{
+				{0, 0, 1, 1, 0, 0},
+				{0, 0, 0, 0, 0, 0},
+				{0, 0, 1, 1, 0, 0},
+			})
+
+			Convey("Returns the steps into, through and out of the corridor", func() {
+				bridges := s.GetBridges()
+
+				So(bridges, ShouldHaveLength, 3)
+				for _, bridge := range bridges {
+					So(bridge.From.ConnectsTo(bridge.To), ShouldBeTrue)
+					So(bridge.FromSize+bridge.ToSize, ShouldEqual, 14)
+				}
+				So(bridges, ShouldContain, Bridge{From: Coord{2, 1}, To: Coord{3, 1}, FromSize: 7, ToSize: 7})
+			})
+		})
+
+		Convey("If wrapped and only two wide", func() {
+			// The coords are next to each other in both directions, so the step between them is not a bridge.
+			s := NewWrappedSurface(2, 1)
+
+			Convey("Returns nothing", func() {
+				So(s.GetBridges(), ShouldBeEmpty)
+			})
+		})
+	})
+}
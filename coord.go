@@ -40,6 +40,14 @@ func (c Coord) GetCoordInDirection(d Direction) Coord {
 		return Coord{c.X + 1, c.Y}
 	case Left:
 		return Coord{c.X - 1, c.Y}
+	case TopRight:
+		return Coord{c.X + 1, c.Y + 1}
+	case BotRight:
+		return Coord{c.X + 1, c.Y - 1}
+	case BotLeft:
+		return Coord{c.X - 1, c.Y - 1}
+	case TopLeft:
+		return Coord{c.X - 1, c.Y + 1}
 	}
 	return c
 }
@@ -86,6 +94,26 @@ func (c Coord) GetCoordsAround() Coords {
 	}
 }
 
+// ManhattanDistanceTo returns the number of horizontal and vertical steps it takes to move from the current coord to
+// the given other coord, ignoring any obstacles.
+func (c Coord) ManhattanDistanceTo(other Coord) int {
+	return abs(c.X-other.X) + abs(c.Y-other.Y)
+}
+
+// GetCoordsAroundWithDiagonals returns all coords around the current coord, including the diagonal ones.
+func (c Coord) GetCoordsAroundWithDiagonals() Coords {
+	return []Coord{
+		{c.X + 1, c.Y},
+		{c.X - 1, c.Y},
+		{c.X, c.Y + 1},
+		{c.X, c.Y - 1},
+		{c.X + 1, c.Y + 1},
+		{c.X + 1, c.Y - 1},
+		{c.X - 1, c.Y - 1},
+		{c.X - 1, c.Y + 1},
+	}
+}
+
 // GetCoordAt returns the coord at the given offset from the current coord.
 func (c Coord) GetCoordAt(xOffset, yOffset int) Coord {
 	return Coord{c.X + xOffset, c.Y + yOffset}
@@ -94,14 +122,17 @@ func (c Coord) GetCoordAt(xOffset, yOffset int) Coord {
 // Coords is an array of coordinates.
 type Coords []Coord
 
-// GetIntersections returns of the given coordinates those that intersect with the current ones.
+// GetIntersections returns of the current coordinates those that are in the given other coordinates. A coord is
+// returned once for each time it is in the other coordinates.
 func (coords Coords) GetIntersections(other []Coord) Coords {
+	counts := make(map[Coord]int, len(other))
+	for _, c := range other {
+		counts[c]++
+	}
 	var intersections []Coord
-	for _, coord1 := range coords {
-		for _, coord2 := range other {
-			if coord1.Equals(coord2) {
-				intersections = append(intersections, coord1)
-			}
+	for _, c := range coords {
+		for i := 0; i < counts[c]; i++ {
+			intersections = append(intersections, c)
 		}
 	}
 	return intersections
@@ -112,15 +143,10 @@ func (coords *Coords) Remove(coordsToRemove ...Coord) {
 	if len(*coords) == 0 || len(coordsToRemove) == 0 {
 		return
 	}
+	toRemove := NewCoordSet(coordsToRemove...)
 	var newCoords Coords
 	for _, c := range *coords {
-		var mustRemove bool
-		for _, cToRemove := range coordsToRemove {
-			if c.Equals(cToRemove) {
-				mustRemove = true
-			}
-		}
-		if !mustRemove {
+		if !toRemove.Has(c) {
 			newCoords = append(newCoords, c)
 		}
 	}
@@ -142,15 +168,9 @@ func (coords Coords) Equals(other Coords) bool {
 	if len(coords) != len(other) {
 		return false
 	}
-	for _, c1 := range coords {
-		var hasMatch bool
-		for _, c2 := range other {
-			if c1.Equals(c2) {
-				hasMatch = true
-				break
-			}
-		}
-		if !hasMatch {
+	otherSet := NewCoordSet(other...)
+	for _, c := range coords {
+		if !otherSet.Has(c) {
 			return false
 		}
 	}
@@ -166,6 +186,25 @@ func (coords Coords) String() string {
 	return strings.Join(chunks, " ")
 }
 
+// mod returns the remainder of dividing `in` by `n`, which unlike the % operator is never negative.
+func mod(in, n int) int {
+	return ((in % n) + n) % n
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func abs(in int) int {
 	return int(math.Abs(float64(in)))
 }
@@ -0,0 +1,120 @@
+package plane
+
+import (
+	"sort"
+	"strings"
+)
+
+// CoordSet is a set of coords. Adding, deleting and looking up a coord take constant time.
+// Unlike Coords, a set holds each coord only once and has no order, but it is iterated in sorted order: by x, then by
+// y, like Surface.GetFilled.
+type CoordSet map[Coord]struct{}
+
+// NewCoordSet returns a set that holds the given coords.
+func NewCoordSet(coords ...Coord) CoordSet {
+	set := make(CoordSet, len(coords))
+	set.Add(coords...)
+	return set
+}
+
+// Add adds the given coords to the set.
+func (set CoordSet) Add(coords ...Coord) {
+	for _, coord := range coords {
+		set[coord] = struct{}{}
+	}
+}
+
+// Has returns true if the given coord is in the set.
+func (set CoordSet) Has(coord Coord) bool {
+	_, ok := set[coord]
+	return ok
+}
+
+// Delete removes the given coords from the set.
+func (set CoordSet) Delete(coords ...Coord) {
+	for _, coord := range coords {
+		delete(set, coord)
+	}
+}
+
+// Len returns the number of coords in the set.
+func (set CoordSet) Len() int {
+	return len(set)
+}
+
+// Union adds the coords of the other set to the set.
+func (set CoordSet) Union(other CoordSet) {
+	for coord := range other {
+		set[coord] = struct{}{}
+	}
+}
+
+// Intersect removes the coords that are not in the other set from the set.
+func (set CoordSet) Intersect(other CoordSet) {
+	for coord := range set {
+		if !other.Has(coord) {
+			delete(set, coord)
+		}
+	}
+}
+
+// Difference removes the coords that are in the other set from the set.
+func (set CoordSet) Difference(other CoordSet) {
+	for coord := range other {
+		delete(set, coord)
+	}
+}
+
+// Equal returns true if both sets hold the same coords.
+func (set CoordSet) Equal(other CoordSet) bool {
+	if len(set) != len(other) {
+		return false
+	}
+	for coord := range set {
+		if !other.Has(coord) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a clone of the set.
+func (set CoordSet) Clone() CoordSet {
+	clone := make(CoordSet, len(set))
+	clone.Union(set)
+	return clone
+}
+
+// Coords returns the coords in the set, sorted by x, then by y.
+func (set CoordSet) Coords() Coords {
+	coords := make(Coords, 0, len(set))
+	for coord := range set {
+		coords = append(coords, coord)
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].X != coords[j].X {
+			return coords[i].X < coords[j].X
+		}
+		return coords[i].Y < coords[j].Y
+	})
+	return coords
+}
+
+// Each calls fn for each coord in the set, sorted by x, then by y, until fn returns false. The set may be changed
+// from within fn, but that does not change which coords fn is called for.
+func (set CoordSet) Each(fn func(coord Coord) bool) {
+	for _, coord := range set.Coords() {
+		if !fn(coord) {
+			return
+		}
+	}
+}
+
+// String satisfies stringer.
+func (set CoordSet) String() string {
+	chunks := make([]string, 0, len(set))
+	for _, coord := range set.Coords() {
+		chunks = append(chunks, coord.String())
+	}
+	return strings.Join(chunks, " ")
+}
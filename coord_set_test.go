@@ -0,0 +1,102 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_CoordSet(t *testing.T) {
+	Convey("CoordSet", t, func() {
+		set := NewCoordSet(Coord{2, 0}, Coord{0, 1}, Coord{0, 0}, Coord{2, 0})
+
+		Convey("Holds each coord once", func() {
+			So(set.Len(), ShouldEqual, 3)
+		})
+
+		Convey("Add(), Has() and Delete()", func() {
+			set.Add(Coord{5, 5})
+			So(set.Has(Coord{5, 5}), ShouldBeTrue)
+
+			set.Delete(Coord{5, 5}, Coord{0, 0}, Coord{9, 9})
+			So(set.Has(Coord{5, 5}), ShouldBeFalse)
+			So(set.Has(Coord{0, 0}), ShouldBeFalse)
+			So(set.Len(), ShouldEqual, 2)
+		})
+
+		Convey("Coords() returns the coords sorted by x, then by y", func() {
+			So(set.Coords(), ShouldResemble, Coords{{0, 0}, {0, 1}, {2, 0}})
+			So(NewCoordSet().Coords(), ShouldResemble, Coords{})
+		})
+
+		Convey("Each() iterates in sorted order until told to stop", func() {
+			var visited Coords
+			set.Each(func(coord Coord) bool {
+				visited = append(visited, coord)
+				return len(visited) < 2
+			})
+
+			So(visited, ShouldResemble, Coords{{0, 0}, {0, 1}})
+		})
+
+		Convey("Each() allows changing the set", func() {
+			var visited Coords
+			set.Each(func(coord Coord) bool {
+				set.Delete(Coord{2, 0})
+				visited = append(visited, coord)
+				return true
+			})
+
+			So(visited, ShouldHaveLength, 3)
+			So(set.Len(), ShouldEqual, 2)
+		})
+
+		Convey("Set operations", func() {
+			other := NewCoordSet(Coord{0, 0}, Coord{3, 3})
+
+			Convey("Union() adds the coords of the other set", func() {
+				set.Union(other)
+
+				So(set.Coords(), ShouldResemble, Coords{{0, 0}, {0, 1}, {2, 0}, {3, 3}})
+			})
+
+			Convey("Intersect() keeps the coords that are in both sets", func() {
+				set.Intersect(other)
+
+				So(set.Coords(), ShouldResemble, Coords{{0, 0}})
+			})
+
+			Convey("Difference() removes the coords of the other set", func() {
+				set.Difference(other)
+
+				So(set.Coords(), ShouldResemble, Coords{{0, 1}, {2, 0}})
+			})
+
+			Convey("The other set is not changed", func() {
+				set.Union(other)
+				set.Intersect(other)
+				set.Difference(other)
+
+				So(other.Coords(), ShouldResemble, Coords{{0, 0}, {3, 3}})
+			})
+		})
+
+		Convey("Equal()", func() {
+			So(set.Equal(NewCoordSet(Coord{0, 0}, Coord{2, 0}, Coord{0, 1})), ShouldBeTrue)
+			So(set.Equal(NewCoordSet(Coord{0, 0}, Coord{2, 0})), ShouldBeFalse)
+			So(set.Equal(NewCoordSet(Coord{0, 0}, Coord{2, 0}, Coord{1, 1})), ShouldBeFalse)
+		})
+
+		Convey("Clone() returns an independent copy", func() {
+			clone := set.Clone()
+			clone.Add(Coord{7, 7})
+
+			So(clone.Len(), ShouldEqual, 4)
+			So(set.Len(), ShouldEqual, 3)
+		})
+
+		Convey("String()", func() {
+			So(set.String(), ShouldEqual, "0,0 0,1 2,0")
+		})
+	})
+}
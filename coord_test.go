@@ -41,6 +41,22 @@ func Test_Coords_GetIntersections(t *testing.T) {
 			},
 			expected: Coords{{3, 1}},
 		},
+		{
+			description: "None the same finds nothing",
+			input: input{
+				base:  Coords{{3, 1}},
+				other: Coords{{1, 1}},
+			},
+			expected: nil,
+		},
+		{
+			description: "Coords in other more than once are found more than once",
+			input: input{
+				base:  Coords{{3, 1}, {1, 1}},
+				other: Coords{{1, 1}, {3, 1}, {1, 1}},
+			},
+			expected: Coords{{3, 1}, {1, 1}, {1, 1}},
+		},
 	}
 
 	Convey("Coords_GetIntersections()", t, func() {
@@ -104,6 +120,14 @@ func Test_Coords_Remove(t *testing.T) {
 			},
 			expected: Coords{{1, 1}, {1, 2}, {2, 1}, {0, 0}},
 		},
+		{
+			description: "remove coords that are in there more than once",
+			input: input{
+				coords:   Coords{{1, 1}, {0, 0}, {1, 1}},
+				toRemove: Coords{{1, 1}},
+			},
+			expected: Coords{{0, 0}},
+		},
 	}
 
 	Convey("Coords.Remove()", t, func() {
@@ -318,6 +342,14 @@ func Test_Coords_Equals(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Returns false if of the same length, but with different coords",
+			input: input{
+				c1: Coords{{0, 0}, {0, 1}},
+				c2: Coords{{0, 0}, {1, 0}},
+			},
+			expected: false,
+		},
 	}
 
 	Convey("Coords.Equals()", t, func() {
@@ -330,3 +362,39 @@ func Test_Coords_Equals(t *testing.T) {
 		}
 	})
 }
+
+func Test_Coord_ManhattanDistanceTo(t *testing.T) {
+	testCases := []struct {
+		description string
+		c1          Coord
+		c2          Coord
+		expected    int
+	}{
+		{
+			description: "Returns 0 for the same coord",
+			c1:          Coord{1, 1},
+			c2:          Coord{1, 1},
+			expected:    0,
+		},
+		{
+			description: "Counts horizontal and vertical steps",
+			c1:          Coord{0, 0},
+			c2:          Coord{3, 2},
+			expected:    5,
+		},
+		{
+			description: "Works in negative directions",
+			c1:          Coord{3, 2},
+			c2:          Coord{0, 4},
+			expected:    5,
+		},
+	}
+
+	Convey("Coord.ManhattanDistanceTo()", t, func() {
+		for i, tc := range testCases {
+			Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
+				So(tc.c1.ManhattanDistanceTo(tc.c2), ShouldEqual, tc.expected)
+			})
+		}
+	})
+}
@@ -10,6 +10,12 @@ const (
 	Right Direction = "right"
 	Bot   Direction = "bot"
 	Left  Direction = "left"
+
+	// Diagonal directions, which are only used on surfaces with EightConnected connectivity.
+	TopRight Direction = "top-right"
+	BotRight Direction = "bot-right"
+	BotLeft  Direction = "bot-left"
+	TopLeft  Direction = "top-left"
 )
 
 // IsVertical returns true if the direction is vertical.
@@ -22,6 +28,11 @@ func (d Direction) IsHorizontal() bool {
 	return d == Left || d == Right
 }
 
+// IsDiagonal returns true if the direction is diagonal.
+func (d Direction) IsDiagonal() bool {
+	return d == TopRight || d == BotRight || d == BotLeft || d == TopLeft
+}
+
 // Opposite returns the opposite direction.
 func (d Direction) Opposite() Direction {
 	switch d {
@@ -33,11 +44,20 @@ func (d Direction) Opposite() Direction {
 		return Left
 	case Left:
 		return Right
+	case TopRight:
+		return BotLeft
+	case BotRight:
+		return TopLeft
+	case BotLeft:
+		return TopRight
+	case TopLeft:
+		return BotRight
 	}
 	return Bot
 }
 
-// NextClockwise returns the next clockwise direction.
+// NextClockwise returns the next clockwise direction, a quarter turn away. Diagonal directions turn into diagonal
+// directions.
 func (d Direction) NextClockwise() Direction {
 	switch d {
 	case Top:
@@ -48,11 +68,20 @@ func (d Direction) NextClockwise() Direction {
 		return Bot
 	case Left:
 		return Top
+	case TopRight:
+		return BotRight
+	case BotRight:
+		return BotLeft
+	case BotLeft:
+		return TopLeft
+	case TopLeft:
+		return TopRight
 	}
 	return Top
 }
 
-// NextCounterClockwise returns the next counter clockwise direction.
+// NextCounterClockwise returns the next counter clockwise direction, a quarter turn away. Diagonal directions turn
+// into diagonal directions.
 func (d Direction) NextCounterClockwise() Direction {
 	switch d {
 	case Top:
@@ -63,13 +92,29 @@ func (d Direction) NextCounterClockwise() Direction {
 		return Top
 	case Left:
 		return Bot
+	case TopRight:
+		return TopLeft
+	case BotRight:
+		return TopRight
+	case BotLeft:
+		return BotRight
+	case TopLeft:
+		return BotLeft
 	}
 	return Top
 }
 
-var allDirections = []Direction{Top, Right, Bot, Left}
+var (
+	allDirections              = []Direction{Top, Right, Bot, Left}
+	allDirectionsWithDiagonals = []Direction{Top, TopRight, Right, BotRight, Bot, BotLeft, Left, TopLeft}
+)
 
-// GetAllDirections returns all available directions.
+// GetAllDirections returns all available directions, not including diagonal directions.
 func GetAllDirections() []Direction {
 	return allDirections
 }
+
+// GetAllDirectionsWithDiagonals returns all available directions, including diagonal directions, in clockwise order.
+func GetAllDirectionsWithDiagonals() []Direction {
+	return allDirectionsWithDiagonals
+}
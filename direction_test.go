@@ -0,0 +1,48 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Direction_Rotation(t *testing.T) {
+	testCases := []struct {
+		direction        Direction
+		opposite         Direction
+		clockwise        Direction
+		counterClockwise Direction
+	}{
+		{Top, Bot, Right, Left},
+		{Right, Left, Bot, Top},
+		{Bot, Top, Left, Right},
+		{Left, Right, Top, Bot},
+		{TopRight, BotLeft, BotRight, TopLeft},
+		{BotRight, TopLeft, BotLeft, TopRight},
+		{BotLeft, TopRight, TopLeft, BotRight},
+		{TopLeft, BotRight, TopRight, BotLeft},
+	}
+
+	Convey("Direction rotation", t, func() {
+		for _, tc := range testCases {
+			Convey(string(tc.direction), func() {
+				So(tc.direction.Opposite(), ShouldEqual, tc.opposite)
+				So(tc.direction.NextClockwise(), ShouldEqual, tc.clockwise)
+				So(tc.direction.NextCounterClockwise(), ShouldEqual, tc.counterClockwise)
+				So(tc.direction.NextClockwise().NextCounterClockwise(), ShouldEqual, tc.direction)
+			})
+		}
+	})
+}
+
+func Test_Direction_IsDiagonal(t *testing.T) {
+	Convey("Direction.IsDiagonal()", t, func() {
+		for _, d := range GetAllDirectionsWithDiagonals() {
+			start := Coord{1, 1}
+			coord := start.GetCoordInDirection(d)
+
+			So(d.IsDiagonal(), ShouldEqual, !start.ConnectsTo(coord))
+			So(coord.GetCoordInDirection(d.Opposite()), ShouldResemble, start)
+		}
+	})
+}
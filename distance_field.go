@@ -0,0 +1,110 @@
+package plane
+
+// DistanceField holds the number of steps it takes to reach each coord of a surface from a given coord, so that many
+// targets can be looked up after a single flood. It is a snapshot: changes to the surface after it was created are not
+// taken into account.
+type DistanceField struct {
+	s    *Surface
+	from Coord
+	// distances holds the distance of each coord, indexed like the cells of the surface. Coords that cannot be reached
+	// have distance 0.
+	distances []int
+	// obstacles holds, indexed like distances, whether a reached coord blocked the flood at the time it was reached.
+	obstacles []bool
+}
+
+// DistanceField floods the surface breadth-first from `from` and returns the number of steps it takes to reach each
+// coord. Like CountSteps, filled coords can be reached, but the flood does not continue past them. At returns the
+// distance to such coords, but Reachable, Max and Each leave them out.
+// It does not change the surface.
+func (f *FloodFiller) DistanceField(from Coord) *DistanceField {
+	distances := f.getDistances(from, f.getStarts(from)...)
+	obstacles := make([]bool, len(distances))
+	for i, distance := range distances {
+		obstacles[i] = distance > 0 && f.isBlocked(f.s.coordAt(i))
+	}
+	return &DistanceField{
+		s:         f.s,
+		from:      from,
+		distances: distances,
+		obstacles: obstacles,
+	}
+}
+
+// DistanceFieldOverTime does the same as DistanceField, but counts the steps like CountStepsOverTime. Coords that are
+// still filled at the step they are reached at are not reached at all.
+func (f *FloodFiller) DistanceFieldOverTime(from Coord) *DistanceField {
+	distances := f.getDistancesOverTime(from, f.getStarts(from)...)
+	return &DistanceField{
+		s:         f.s,
+		from:      from,
+		distances: distances,
+		obstacles: make([]bool, len(distances)),
+	}
+}
+
+// From returns the coord from which the distances were counted.
+func (d *DistanceField) From() Coord {
+	return d.from
+}
+
+// At returns the smallest number of steps it takes to reach the given coord, which may be filled.
+// Returns false if the coord cannot be reached.
+func (d *DistanceField) At(coord Coord) (int, bool) {
+	coord = d.s.Wrap(coord)
+	if !d.s.Fits(coord) {
+		return -1, false
+	}
+	distance := d.distances[d.s.index(coord)]
+	if distance == 0 {
+		return -1, false
+	}
+	return distance, true
+}
+
+// reachedAt returns the number of steps it takes to reach the given coord, like At, but returns false for the filled
+// coords at which the flood stopped.
+func (d *DistanceField) reachedAt(coord Coord) (int, bool) {
+	distance, ok := d.At(coord)
+	if !ok || d.obstacles[d.s.index(d.s.Wrap(coord))] {
+		return -1, false
+	}
+	return distance, true
+}
+
+// Reachable returns all coords that can be reached, leaving out the filled coords at which the flood stopped.
+func (d *DistanceField) Reachable() Coords {
+	reachable := Coords{}
+	d.Each(func(coord Coord, distance int) bool {
+		reachable = append(reachable, coord)
+		return true
+	})
+	return reachable
+}
+
+// Max returns the number of steps it takes to reach the coord that is furthest away, leaving out the filled coords at
+// which the flood stopped.
+// Returns 0 if no coord can be reached.
+func (d *DistanceField) Max() int {
+	var furthest int
+	d.Each(func(coord Coord, distance int) bool {
+		if distance > furthest {
+			furthest = distance
+		}
+		return true
+	})
+	return furthest
+}
+
+// Each calls `fn` for each coord that can be reached, with the number of steps it takes to reach it, until `fn`
+// returns false. Like Reachable, it leaves out the filled coords at which the flood stopped.
+func (d *DistanceField) Each(fn func(coord Coord, distance int) bool) {
+	for i, distance := range d.distances {
+		if distance == 0 || d.obstacles[i] {
+			continue
+		}
+		if !fn(d.s.coordAt(i), distance) {
+			return
+		}
+	}
+}
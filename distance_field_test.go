@@ -0,0 +1,109 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_DistanceField(t *testing.T) {
+	Convey("FloodFiller.DistanceField()", t, func() {
+		// (F = from, x = filled)
+		// . . x
+		// . . x
+		// F . x .
+		s := NewSurface(4, 3)
+		s.fillRows([][]int{
+			{0, 0, 1, 0},
+			{0, 0, 1, 0},
+			{0, 0, 1, 0},
+		})
+		from := Coord{0, 0}
+		field := NewFloodFiller(s).DistanceField(from)
+
+		Convey("At()", func() {
+			Convey("Returns the same number of steps as CountSteps", func() {
+				for _, target := range []Coord{{1, 0}, {1, 2}, {0, 2}, {2, 1}} {
+					distance, ok := field.At(target)
+
+					So(ok, ShouldBeTrue)
+					So(distance, ShouldEqual, NewFloodFiller(s.Clone()).CountSteps(from, target))
+				}
+			})
+
+			Convey("Returns false for coords that cannot be reached", func() {
+				distance, ok := field.At(Coord{3, 0})
+
+				So(ok, ShouldBeFalse)
+				So(distance, ShouldEqual, -1)
+			})
+
+			Convey("Returns false for the coord it counts from", func() {
+				_, ok := field.At(from)
+
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Returns false for coords that do not fit", func() {
+				_, ok := field.At(Coord{4, 0})
+
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("Reachable()", func() {
+			Convey("Returns all coords that can be reached, except filled ones", func() {
+				So(field.Reachable(), ShouldResemble, Coords{
+					{1, 0},
+					{0, 1}, {1, 1},
+					{0, 2}, {1, 2},
+				})
+			})
+		})
+
+		Convey("Max()", func() {
+			Convey("Returns the largest number of steps to a coord that is not filled", func() {
+				So(field.Max(), ShouldEqual, 3)
+			})
+
+			Convey("Returns 0 if nothing can be reached", func() {
+				So(NewFloodFiller(NewSurface(1, 1)).DistanceField(from).Max(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("Each()", func() {
+			Convey("Calls the func for each reachable coord that is not filled", func() {
+				distances := map[Coord]int{}
+				field.Each(func(coord Coord, distance int) bool {
+					distances[coord] = distance
+					return true
+				})
+
+				So(distances, ShouldHaveLength, 5)
+				So(distances[Coord{1, 2}], ShouldEqual, 3)
+				So(distances, ShouldNotContainKey, Coord{2, 2})
+			})
+
+			Convey("Stops when the func returns false", func() {
+				var numCalls int
+				field.Each(func(coord Coord, distance int) bool {
+					numCalls++
+					return false
+				})
+
+				So(numCalls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("Is not changed by later changes to the surface", func() {
+			s.Fill(Coord{1, 0})
+			s.Remove(Coord{2, 0})
+
+			distance, ok := field.At(Coord{1, 0})
+			So(ok, ShouldBeTrue)
+			So(distance, ShouldEqual, 1)
+			So(field.Reachable(), ShouldContain, Coord{1, 0})
+			So(field.Reachable(), ShouldNotContain, Coord{2, 0})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package plane
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// binaryVersion is the version of the binary form of surfaces, which is written as its first byte.
+const binaryVersion = 1
+
+// maxJSONSize is the largest number of coords that a surface in JSON form can have. Unlike the binary form, the JSON
+// form does not hold a bit for each coord, so the size has to be limited to keep the surface from taking up all memory.
+const maxJSONSize = 1 << 24
+
+// Flags in the binary form of surfaces.
+const (
+	binaryFlagWrapped = 1 << iota
+	binaryFlagEightConnected
+)
+
+// surfaceJSON is the JSON form of a surface.
+type surfaceJSON struct {
+	Width        int          `json:"width"`
+	Height       int          `json:"height"`
+	Wrapped      bool         `json:"wrapped,omitempty"`
+	Connectivity Connectivity `json:"connectivity,omitempty"`
+	Filled       Coords       `json:"filled"`
+}
+
+// MarshalJSON satisfies json.Marshaler. The width, height, wrapping, connectivity and filled coords are preserved.
+// Costs, cells, distances and the steps until which coords are filled are not.
+func (s *Surface) MarshalJSON() ([]byte, error) {
+	filled := s.GetFilled()
+	if filled == nil {
+		filled = Coords{}
+	}
+	return json.Marshal(surfaceJSON{
+		Width:        s.width,
+		Height:       s.height,
+		Wrapped:      s.wrapped,
+		Connectivity: s.connectivity,
+		Filled:       filled,
+	})
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler. It returns an error for surfaces of more than 1 << 24 coords.
+func (s *Surface) UnmarshalJSON(data []byte) error {
+	var v surfaceJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("could not unmarshal surface: %w", err)
+	}
+	if v.Width < 0 || v.Height < 0 {
+		return fmt.Errorf("could not unmarshal surface: invalid size %dx%d", v.Width, v.Height)
+	}
+	// Compare by dividing, so that the size cannot overflow.
+	if v.Width != 0 && v.Height > maxJSONSize/v.Width {
+		return fmt.Errorf("could not unmarshal surface: size %dx%d is too large", v.Width, v.Height)
+	}
+	if v.Connectivity == 0 {
+		v.Connectivity = FourConnected
+	}
+	if !v.Connectivity.isValid() {
+		return fmt.Errorf("could not unmarshal surface: invalid connectivity %d", v.Connectivity)
+	}
+
+	unmarshaled := NewSurface(v.Width, v.Height)
+	unmarshaled.wrapped = v.Wrapped
+	unmarshaled.connectivity = v.Connectivity
+	for _, coord := range v.Filled {
+		if !unmarshaled.Fits(coord) {
+			return fmt.Errorf("could not unmarshal surface: filled coord %s does not fit", coord)
+		}
+		unmarshaled.Fill(coord)
+	}
+	*s = *unmarshaled
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler. The text form is the render of GetRender, so only the width, height,
+// wrapping and filled coords are preserved. A render of a surface of height 0 has no rows to tell its width from, so
+// such surfaces cannot be encoded as text, and an error is returned for them.
+func (s *Surface) MarshalText() ([]byte, error) {
+	if s.height == 0 {
+		return nil, fmt.Errorf("could not marshal surface: a %dx%d surface has no rows to render", s.width, s.height)
+	}
+	return []byte(strings.TrimPrefix(GetRender(s), "\n")), nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler. It accepts anything that ParseSurface accepts.
+func (s *Surface) UnmarshalText(text []byte) error {
+	parsed, err := ParseSurface(string(text))
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// MarshalBinary satisfies encoding.BinaryMarshaler. The binary form is compact: after a version byte, a byte of flags
+// and the width and height as uvarints, every coord takes up a single bit, which is set if the coord is filled. Like in
+// the JSON form, costs, cells, distances and the steps until which coords are filled are not preserved.
+func (s *Surface) MarshalBinary() ([]byte, error) {
+	var flags byte
+	if s.wrapped {
+		flags |= binaryFlagWrapped
+	}
+	if s.connectivity == EightConnected {
+		flags |= binaryFlagEightConnected
+	}
+
+	header := make([]byte, 2+2*binary.MaxVarintLen64)
+	header[0] = binaryVersion
+	header[1] = flags
+	n := 2
+	n += binary.PutUvarint(header[n:], uint64(s.width))
+	n += binary.PutUvarint(header[n:], uint64(s.height))
+
+	data := make([]byte, n+(len(s.cells)+7)/8)
+	copy(data, header[:n])
+	bits := data[n:]
+	for i, cell := range s.cells {
+		if cell.isFilled {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (s *Surface) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("could not unmarshal surface: data too short")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("could not unmarshal surface: unknown version %d", data[0])
+	}
+	flags := data[1]
+	data = data[2:]
+
+	width, n := binary.Uvarint(data)
+	if n <= 0 {
+		return fmt.Errorf("could not unmarshal surface: invalid width")
+	}
+	data = data[n:]
+	height, n := binary.Uvarint(data)
+	if n <= 0 {
+		return fmt.Errorf("could not unmarshal surface: invalid height")
+	}
+	data = data[n:]
+
+	// Compare against the number of bits that are available, so that the size cannot overflow.
+	available := uint64(len(data)) * 8
+	if width > available || height > available || (width != 0 && height > available/width) {
+		return fmt.Errorf("could not unmarshal surface: data too short for size %dx%d", width, height)
+	}
+	size := int(width * height)
+	if len(data) != (size+7)/8 {
+		return fmt.Errorf("could not unmarshal surface: expected %d bytes of cells, got %d", (size+7)/8, len(data))
+	}
+
+	unmarshaled := NewSurface(int(width), int(height))
+	unmarshaled.wrapped = flags&binaryFlagWrapped != 0
+	if flags&binaryFlagEightConnected != 0 {
+		unmarshaled.connectivity = EightConnected
+	}
+	for i := range unmarshaled.cells {
+		unmarshaled.cells[i].isFilled = data[i/8]&(1<<(i%8)) != 0
+	}
+	*s = *unmarshaled
+	return nil
+}
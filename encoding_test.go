@@ -0,0 +1,197 @@
+package plane
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getEncodingTestSurfaces() []*Surface {
+	small := NewSurface(3, 2)
+	small.Fill(Coord{0, 0}, Coord{2, 1})
+	odd := NewSurface(7, 3)
+	odd.Fill(Coord{6, 2}, Coord{3, 1})
+	wrapped := NewWrappedSurface(11, 11)
+	wrapped.Fill(Coord{0, 10}, Coord{10, 0}, Coord{5, 5})
+	eightConnected := NewSurface(4, 4)
+	eightConnected.SetConnectivity(EightConnected)
+	eightConnected.Fill(Coord{1, 1})
+
+	return []*Surface{small, odd, wrapped, eightConnected, NewSurface(5, 5), NewSurface(0, 0)}
+}
+
+func Test_Surface_JSON(t *testing.T) {
+	Convey("Surface JSON encoding", t, func() {
+		Convey("Round-trips", func() {
+			for i, s := range getEncodingTestSurfaces() {
+				Convey(fmt.Sprintf("%d: %dx%d", i, s.width, s.height), func() {
+					data, err := json.Marshal(s)
+					So(err, ShouldBeNil)
+
+					var unmarshaled Surface
+					So(json.Unmarshal(data, &unmarshaled), ShouldBeNil)
+					So(&unmarshaled, ShouldResemble, s)
+				})
+			}
+		})
+
+		Convey("Lists the filled coords", func() {
+			s := NewWrappedSurface(2, 2)
+			s.Fill(Coord{1, 0})
+
+			data, err := json.Marshal(s)
+
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"width":2,"height":2,"wrapped":true,"connectivity":4,"filled":[{"x":1,"y":0}]}`)
+		})
+
+		Convey("Works for surfaces in other values", func() {
+			s := NewSurface(2, 2)
+			s.Fill(Coord{0, 1})
+			fixture := struct {
+				Surface *Surface `json:"surface"`
+			}{s}
+
+			data, err := json.Marshal(fixture)
+			So(err, ShouldBeNil)
+
+			fixture.Surface = nil
+			So(json.Unmarshal(data, &fixture), ShouldBeNil)
+			So(fixture.Surface, ShouldResemble, s)
+		})
+
+		Convey("Defaults to FourConnected", func() {
+			var s Surface
+
+			So(json.Unmarshal([]byte(`{"width":2,"height":1,"filled":[]}`), &s), ShouldBeNil)
+			So(s.GetConnectivity(), ShouldEqual, FourConnected)
+		})
+
+		Convey("Returns an error if the JSON is invalid", func() {
+			testCases := []struct {
+				description string
+				json        string
+			}{
+				{"if it is malformed", `{"width":`},
+				{"if the size is negative", `{"width":-1,"height":2}`},
+				{"if the size overflows", `{"width":4294967296,"height":4294967296}`},
+				{"if the size is too large", `{"width":3037000500,"height":3037000500}`},
+				{"if the connectivity is unknown", `{"width":1,"height":1,"connectivity":6}`},
+				{"if a filled coord does not fit", `{"width":1,"height":1,"filled":[{"x":1,"y":0}]}`},
+			}
+
+			for i, tc := range testCases {
+				Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
+					var s Surface
+
+					So(json.Unmarshal([]byte(tc.json), &s), ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
+
+func Test_Surface_Text(t *testing.T) {
+	Convey("Surface text encoding", t, func() {
+		Convey("Round-trips", func() {
+			for i, s := range getEncodingTestSurfaces()[:3] {
+				Convey(fmt.Sprintf("%d: %dx%d", i, s.width, s.height), func() {
+					text, err := s.MarshalText()
+					So(err, ShouldBeNil)
+
+					var unmarshaled Surface
+					So(unmarshaled.UnmarshalText(text), ShouldBeNil)
+					So(&unmarshaled, ShouldResemble, s)
+				})
+			}
+		})
+
+		Convey("Is the render of the surface", func() {
+			s := NewSurface(2, 1)
+			s.Fill(Coord{0, 0})
+
+			text, err := s.MarshalText()
+
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "00 | x .\n    ----\n     0 1")
+		})
+
+		Convey("Round-trips surfaces without columns", func() {
+			s := NewSurface(0, 3)
+
+			text, err := s.MarshalText()
+			So(err, ShouldBeNil)
+
+			var unmarshaled Surface
+			So(unmarshaled.UnmarshalText(text), ShouldBeNil)
+			So(&unmarshaled, ShouldResemble, s)
+		})
+
+		Convey("Returns an error for surfaces without rows", func() {
+			for _, s := range []*Surface{NewSurface(0, 0), NewSurface(3, 0)} {
+				text, err := s.MarshalText()
+
+				So(err, ShouldNotBeNil)
+				So(text, ShouldBeNil)
+			}
+		})
+
+		Convey("Returns an error if the text cannot be parsed", func() {
+			var s Surface
+
+			So(s.UnmarshalText([]byte("x ?")), ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_Surface_Binary(t *testing.T) {
+	Convey("Surface binary encoding", t, func() {
+		Convey("Round-trips", func() {
+			for i, s := range getEncodingTestSurfaces() {
+				Convey(fmt.Sprintf("%d: %dx%d", i, s.width, s.height), func() {
+					data, err := s.MarshalBinary()
+					So(err, ShouldBeNil)
+
+					var unmarshaled Surface
+					So(unmarshaled.UnmarshalBinary(data), ShouldBeNil)
+					So(&unmarshaled, ShouldResemble, s)
+				})
+			}
+		})
+
+		Convey("Packs a cell in each bit", func() {
+			s := NewSurface(3, 3)
+			s.Fill(Coord{0, 0}, Coord{2, 2})
+
+			data, err := s.MarshalBinary()
+
+			So(err, ShouldBeNil)
+			So(data, ShouldResemble, []byte{binaryVersion, 0, 3, 3, 0b00000001, 0b00000001})
+		})
+
+		Convey("Returns an error if the data is invalid", func() {
+			testCases := []struct {
+				description string
+				data        []byte
+			}{
+				{"if it is empty", nil},
+				{"if the version is unknown", []byte{2, 0, 1, 1, 0}},
+				{"if the width is missing", []byte{binaryVersion, 0}},
+				{"if the height is missing", []byte{binaryVersion, 0, 3}},
+				{"if cells are missing", []byte{binaryVersion, 0, 3, 3, 0}},
+				{"if there are too many cells", []byte{binaryVersion, 0, 1, 1, 0, 0}},
+				{"if the size is too large", []byte{binaryVersion, 0, 0xff, 0xff, 0xff, 0xff, 0x0f, 0xff, 0xff, 0xff, 0xff, 0x0f, 0}},
+			}
+
+			for i, tc := range testCases {
+				Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
+					var s Surface
+
+					So(s.UnmarshalBinary(tc.data), ShouldNotBeNil)
+				})
+			}
+		})
+	})
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/minitauros/go-plane"
 )
 
@@ -22,11 +24,17 @@ func main() {
 	// by the flood.
 	filledCoords := ff.Flood(plane.Coord{0, 0}, plane.Coord{0, 1})
 	numFilled := len(filledCoords)
+	fmt.Println(numFilled)
 
 	// Return the quickest path from 0,0 to 4,4.
 	// This will go around obstacles.
 	ff.CountSteps(plane.Coord{0, 0}, plane.Coord{0, 1}) // 9
 
+	// Return the coords that make up the quickest path from 0,0 to 4,4.
+	// The path starts with the first step after 0,0 and ends at 4,4.
+	// Returns false if 4,4 cannot be reached.
+	ff.ShortestPath(plane.Coord{0, 0}, plane.Coord{4, 4}) // Coords{{1, 0}, {2, 0}, ...}, true
+
 	// Returns true if 5,5 can be reached, i.e. if there are no
 	// obstacles (filled coords) in the way that the flood cannot pass
 	// through in some way.
@@ -37,4 +45,36 @@ func main() {
 	// through in some way.
 	// Forces the flood to start at 0,1 and gives it no other options.
 	ff.CanReachWhenStartingFloodAt(plane.Coord{0, 0}, plane.Coord{4, 4}, plane.Coord{0, 1})
+
+	// Read only variants of Flood, CountSteps and CanReach.
+	// These do not change the surface, so there is no need to clone it,
+	// and the same surface can be queried multiple times, even concurrently.
+	ff.FloodReadOnly(plane.Coord{0, 0}, plane.Coord{0, 1})      // Coords{...}
+	ff.CountStepsReadOnly(plane.Coord{0, 0}, plane.Coord{4, 4}) // 8
+	ff.CanReachReadOnly(plane.Coord{0, 0}, plane.Coord{4, 4})   // True
+
+	// Count the steps to all coords at once, to answer many
+	// questions with a single flood. Does not change the surface.
+	field := ff.DistanceField(plane.Coord{0, 0})
+	field.At(plane.Coord{4, 4}) // 8, true
+	field.Reachable()           // Coords{{1, 0}, {2, 0}, ...}
+	field.Max()                 // 8
+
+	// Count steps, taking into account that coords that are filled
+	// until a given step (see Surface.FillUntil), such as the body of a
+	// snake of which the tail moves away, are free from that step on.
+	surface.FillUntil(2, plane.Coord{2, 2})
+	ff.CountStepsOverTime(plane.Coord{0, 0}, plane.Coord{4, 4}) // 8
+
+	// Make cells of given kinds block the flood, as if they were filled.
+	// By default only filled coords block the flood.
+	ff.SetBlockingKinds(plane.CellBody, plane.CellHazard)
+
+	// Return for each given source (e.g. snake heads) the coords it reaches
+	// before any of the other sources, and the coords it reaches at the same
+	// time as one or more other sources. Does not change the surface.
+	territories := ff.GetTerritories(plane.Coord{0, 0}, plane.Coord{4, 4})
+	territories[0].Size() // 9
+	tied := territories[0].Tied
+	fmt.Println(tied) // Coords{{4, 0}, {3, 1}, ...}
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/minitauros/go-plane"
+)
+
+func main() {
+	// Path finder needs a surface to work with.
+	surface := plane.NewSurface(5, 5)
+
+	// Create new path finder.
+	// Unlike the flood filler, the path finder does not change the surface,
+	// so there is no need to clone it.
+	pf := plane.NewPathFinder(surface)
+
+	// Return the cheapest path from 0,0 to 4,4 and its cost, using A*.
+	// This will go around obstacles.
+	// The path starts with the first step after 0,0 and ends at 4,4.
+	// Returns false if 4,4 cannot be reached.
+	pf.FindPath(plane.Coord{0, 0}, plane.Coord{4, 4}) // Coords{{1, 0}, {2, 0}, ...}, 8, true
+
+	// Return the cheapest path from 0,0 to 4,4 and its cost, using Dijkstra.
+	// Unlike FindPath, this ignores the heuristic.
+	pf.CheapestPath(plane.Coord{0, 0}, plane.Coord{4, 4}) // Coords{{1, 0}, {2, 0}, ...}, 8, true
+
+	// Use a different heuristic to estimate the cost of reaching the target.
+	// By default surface.CountStepsIgnoringObstacles is used, which takes
+	// wrapping and diagonal moves into account. With a heuristic that
+	// estimates more than the real cost, such as plane.ManhattanDistance
+	// on wrapped or EightConnected surfaces, FindPath may no longer return
+	// the cheapest path. Pass nil to go back to the default.
+	pf.SetHeuristic(func(from, to plane.Coord) int {
+		return 0
+	})
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"github.com/minitauros/go-plane"
 )
 
@@ -31,9 +35,18 @@ func main() {
 	surface.IsFilled(plane.Coord{0, 0}) // False
 
 	// Iterate over all filled coords.
-	for coord := range surface.EachFilled() {
-		// Do something..
+	for coord := range surface.Filled() {
+		fmt.Println(coord) // 0,1 then 1,0
+	}
+
+	// Or over all coords and whether they are filled, the unfilled
+	// coords, the coords in a rectangle, or the coords next to a coord.
+	for coord, isFilled := range surface.All() {
+		fmt.Println(coord, isFilled) // 0,0 false then 0,1 true, ...
 	}
+	surface.Unfilled()                                   // iter.Seq[Coord]
+	surface.InRect(plane.Coord{1, 1}, plane.Coord{3, 3}) // iter.Seq[Coord]
+	surface.Neighbours(plane.Coord{2, 2})                // iter.Seq[Coord]
 
 	// Getting all filled coords at once.
 	surface.GetFilled() // Coords{{0, 0}, {1, 0}, {0, 1}}
@@ -50,10 +63,120 @@ func main() {
 	// Getting the center coord.
 	surface.GetCenter() // plane.Coord{2, 2}
 
+	// Fill coords until a given step, after which they are free again,
+	// e.g. for snake bodies of which the tail moves away.
+	surface.FillUntil(2, plane.Coord{3, 3})
+	surface.IsFilledAt(plane.Coord{3, 3}, 1) // True
+	surface.IsFilledAt(plane.Coord{3, 3}, 2) // False
+
+	// Make coords more expensive to move onto, e.g. for hazards.
+	// By default each coord costs 1.
+	surface.SetCost(5, plane.Coord{2, 2})
+	surface.GetCost(plane.Coord{2, 2}) // 5
+
+	// Tell what is at each coord, e.g. the body of a given snake, food or
+	// hazards. Cells do not fill coords. Coords off the surface are walls.
+	surface.SetCell(plane.Cell{Kind: plane.CellBody, Owner: 1}, plane.Coord{3, 4}, plane.Coord{4, 4})
+	surface.SetCell(plane.Cell{Kind: plane.CellFood}, plane.Coord{2, 3})
+	surface.GetCell(plane.Coord{2, 3})      // Cell{Kind: CellFood}
+	surface.CellsOfKind(plane.CellBody)     // Coords{{3, 4}, {4, 4}}
+	surface.CellsOfOwner(plane.CellBody, 1) // Coords{{3, 4}, {4, 4}}
+
 	// Checking if a coord fits.
 	surface.Fits(plane.Coord{-1, -1}) // False
 	surface.Fits(plane.Coord{0, 0})   // True
 
+	// Create a wrapped surface, of which the edges connect to the
+	// opposite edges, like in Battlesnake's "wrapped" game mode.
+	// Flood filling and path finding go across the edges.
+	wrapped := plane.NewWrappedSurface(5, 5)
+	wrapped.Wrap(plane.Coord{-1, 0}) // plane.Coord{4, 0}
+
+	// Allow moving diagonally. Flood filling and path finding will then
+	// move diagonally too. By default surfaces are plane.FourConnected.
+	// Any other connectivity is rejected with an error.
+	surface.SetConnectivity(plane.EightConnected)
+	surface.GetCoordsAround(plane.Coord{2, 2}) // Coords{{3, 2}, {1, 2}, ...}, 8 coords
+
+	// Get the separate regions of unfilled coords, their sizes and
+	// the rectangles that contain them.
+	regions := surface.GetRegions()
+	regions.Count()                     // 1
+	regions.Largest()                   // Region{ID: 0, Coords: Coords{...}, Min: ..., Max: ...}, true
+	regions.RegionOf(plane.Coord{4, 4}) // Region{ID: 0, ...}, true
+
+	// Get the unfilled coords that would split the space they are in
+	// if they were filled, e.g. the entrance of a corridor, and the
+	// sizes of the spaces they would split it into.
+	surface.GetChokepoints() // []Chokepoint{{Coord: ..., RegionSizes: []int{...}}, ...}
+
+	// Get the steps between unfilled coords that are the only way from
+	// one part of a space to another.
+	surface.GetBridges() // []Bridge{{From: ..., To: ..., FromSize: ..., ToSize: ...}, ...}
+
+	// Parse a surface from the output of plane.GetRender(), or from a
+	// board without axes, e.g. in tests and bug reports.
+	plane.ParseSurface(`
+		. . x
+		x . .
+	`) // *Surface, nil
+
+	// Other characters can be given a meaning as well.
+	plane.ParseSurfaceWithGlyphs("# h .", map[rune]plane.Glyph{
+		'#': {Filled: true},
+		'h': {Cost: 16},
+	}) // *Surface, nil
+
+	// Surfaces can be stored and shipped as JSON, as text (the render
+	// of plane.GetRender()) or in a compact binary form, in which each
+	// coord takes up a single bit.
+	json.Marshal(surface)   // {"width":5,"height":5,"connectivity":8,"filled":[...]}, nil
+	surface.MarshalText()   // []byte, nil
+	surface.MarshalBinary() // []byte, nil
+
+	// Render the surface in a terminal, in colour, with layers drawn on
+	// top of each other, e.g. a planned path and the heads of opponents.
+	plane.GetANSIRender(surface, plane.ANSIRenderOptions{
+		Layers: []plane.Layer{
+			plane.FilledLayer{Style: plane.CellStyle{Foreground: plane.ANSIBrightBlack}},
+			plane.PathLayer{Path: plane.Coords{{0, 2}, {1, 2}, {2, 2}}, Style: plane.CellStyle{Foreground: plane.ANSIGreen}},
+			plane.CoordsLayer{Coords: plane.Coords{{4, 4}}, Style: plane.CellStyle{Glyph: "H", Background: plane.ANSIRed}},
+		},
+	}) // "\n04 | · · · · \x1b[48;5;1m H\x1b[0m\n..."
+
+	// Draw the surface as an image, e.g. to look at boards that are too
+	// large to read as text. Paths and highlighted coords can be drawn on
+	// top, as well as a heatmap of the distances after a flood, or of a
+	// distance field.
+	plane.GetImage(surface, plane.ImageOptions{
+		CellSize:    32,
+		Paths:       []plane.Coords{{{0, 2}, {1, 2}, {2, 2}}},
+		Highlighted: plane.Coords{{4, 4}},
+	}) // image.Image
+	plane.WritePNG(os.Stdout, surface, plane.ImageOptions{}) // nil
+	plane.GetSVG(surface, plane.ImageOptions{Heatmap: true}) // "<svg ..."
+	plane.GetSVG(surface, plane.ImageOptions{
+		Distances: plane.NewFloodFiller(surface).DistanceField(plane.Coord{0, 0}),
+	}) // "<svg ..."
+
+	// Combine surfaces of the same size, e.g. your body, the reach of
+	// opponents and hazards. The methods change the surface, the
+	// functions return a new surface. Both return an error if the
+	// surfaces differ in size.
+	other := plane.NewSurface(5, 5)
+	surface.Union(other)        // nil, fill what is filled on either surface.
+	surface.Intersect(other)    // nil, fill what is filled on both surfaces.
+	surface.Difference(other)   // nil, remove what is filled on the other surface.
+	surface.Invert()            // Fill what is not filled, and vice versa.
+	plane.Union(surface, other) // *Surface, nil
+	surface.Equal(other)        // True if the same coords are filled, and wrapping and connectivity are the same.
+
+	// Keep track of coords in a set, in which adding, deleting and looking
+	// up a coord take constant time.
+	set := plane.NewCoordSet(surface.GetFilled()...)
+	set.Has(plane.Coord{0, 1}) // True
+	set.Coords()               // Coords{...}, sorted by x, then by y
+
 	// Clone the surface.
 	// This is useful when passing it to the flood filler, as the flood
 	// filler will change the surface's state, and you may want to remember
@@ -3,6 +3,8 @@ package plane
 // FloodFiller is a flood filler.
 type FloodFiller struct {
 	s *Surface
+	// blockingKinds are the kinds of cells that the flood cannot pass, besides filled coords.
+	blockingKinds []CellKind
 }
 
 // NewFloodFiller returns a new flood filler.
@@ -12,11 +14,17 @@ func NewFloodFiller(surface *Surface) *FloodFiller {
 	}
 }
 
+// SetBlockingKinds sets the kinds of cells that the flood cannot pass, as if they were filled, for example bodies and
+// hazards. By default, only filled coords block the flood. See Surface.SetCell.
+func (f *FloodFiller) SetBlockingKinds(kinds ...CellKind) {
+	f.blockingKinds = kinds
+}
+
 // Flood starts a flood fill from `base`, starting the flood at `startAt`.
 // It returns the number of coords that were filled.
 // It does not flood `base`.
 func (f *FloodFiller) Flood(base, startAt Coord) Coords {
-	if !base.ConnectsTo(startAt) {
+	if !f.s.connects(base, startAt) {
 		return Coords{}
 	}
 	coordsFilled := f.flood(base, startAt, false)
@@ -42,15 +50,104 @@ func (f *FloodFiller) CountSteps(base, target Coord) int {
 	return f.s.getDistance(target)
 }
 
+// FloodReadOnly returns the coords that Flood would fill, but does not change the surface.
+// Unlike the other flood methods, it can be used on a surface that is shared with other goroutines, as long as they
+// do not change the surface either.
+func (f *FloodFiller) FloodReadOnly(base, startAt Coord) Coords {
+	if !f.s.connects(base, startAt) {
+		return Coords{}
+	}
+	filled := Coords{}
+	for i, distance := range f.getDistances(base, f.s.Wrap(startAt)) {
+		if distance > 0 && !f.s.cells[i].isFilled && !f.blocksKind(f.s.cells[i].kind) {
+			filled = append(filled, f.s.coordAt(i))
+		}
+	}
+	return filled
+}
+
+// CanReachReadOnly returns true if a path can be made through unfilled coords from `base` to `target`.
+// It does not change the surface. See FloodReadOnly.
+func (f *FloodFiller) CanReachReadOnly(base, target Coord) bool {
+	return f.CountStepsReadOnly(base, target) != -1
+}
+
+// CountStepsReadOnly returns the smallest number of steps that can be taken to reach `target` from `base`, or -1 if
+// `target` cannot be reached. It does not change the surface. See FloodReadOnly.
+// To count the steps to many targets, use DistanceField instead.
+func (f *FloodFiller) CountStepsReadOnly(base, target Coord) int {
+	distance, _ := f.DistanceField(base).At(target)
+	return distance
+}
+
+// CountStepsOverTime returns the smallest number of steps that can be taken to reach `target` from `base`, or -1 if
+// `target` cannot be reached. Unlike CountSteps, it treats coords that are filled until a given step (see
+// Surface.FillUntil) as free if they are reached at or after that step, and it does not count `target` as reached if
+// it is filled when it is reached. It does not change the surface.
+func (f *FloodFiller) CountStepsOverTime(base, target Coord) int {
+	distance, _ := f.DistanceFieldOverTime(base).At(target)
+	return distance
+}
+
+// ShortestPath returns the coords that make up the shortest path from `base` to `target`.
+// The path does not include `base`, but does include `target`, so the first coord is the first step to take and the
+// length of the path equals the number of steps returned by CountSteps.
+// Returns false if no path can be made.
+func (f *FloodFiller) ShortestPath(base, target Coord) (Coords, bool) {
+	if base.Equals(target) {
+		return Coords{}, true
+	}
+	numSteps := f.CountSteps(base, target)
+	if numSteps == -1 {
+		return nil, false
+	}
+
+	// Walk back from the target, each time stepping to an unfilled coord that is one step closer to base.
+	path := make(Coords, numSteps)
+	path[numSteps-1] = target
+	cur := target
+	for i := numSteps - 2; i >= 0; i-- {
+		var found bool
+		for _, c := range f.s.GetCoordsAround(cur) {
+			if f.isBlocked(c) || f.s.getDistance(c) != i+1 {
+				continue
+			}
+			path[i] = c
+			cur = c
+			found = true
+			break
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return path, true
+}
+
 func (f *FloodFiller) canReach(base, target Coord, countSteps bool, allowedStarts ...Coord) bool {
-	distanceBefore := f.s.getDistance(target)
 	filledAroundBefore := f.s.getCoordsFilledAround(target)
-	for _, d := range GetAllDirections() {
-		coordInDirection := base.GetCoordInDirection(d)
+	starts := f.getStarts(base, allowedStarts...)
+	if countSteps {
+		f.floodDistance(base, starts...)
+		return f.s.hasDistance(target)
+	}
+	for _, start := range starts {
+		f.flood(base, start, false)
+	}
+	filledAroundAfter := f.s.getCoordsFilledAround(target)
+	return len(filledAroundAfter) > len(filledAroundBefore)
+}
+
+// getStarts returns the coords around `base` at which a flood may start.
+// If `allowedStarts` are given, only those coords are returned.
+func (f *FloodFiller) getStarts(base Coord, allowedStarts ...Coord) Coords {
+	starts := make(Coords, 0, 4)
+	for _, d := range f.s.getDirections() {
+		coordInDirection := f.s.getCoordInDirection(base, d)
 		if len(allowedStarts) > 0 {
 			var mayStartInThisDirection bool
 			for _, allowedStart := range allowedStarts {
-				if coordInDirection == allowedStart {
+				if coordInDirection == f.s.Wrap(allowedStart) {
 					mayStartInThisDirection = true
 					break
 				}
@@ -59,25 +156,23 @@ func (f *FloodFiller) canReach(base, target Coord, countSteps bool, allowedStart
 				continue
 			}
 		}
-		f.flood(base, coordInDirection, countSteps)
+		starts = append(starts, coordInDirection)
 	}
-	if countSteps {
-		return distanceBefore != f.s.getDistance(target)
-	}
-	filledAroundAfter := f.s.getCoordsFilledAround(target)
-	return len(filledAroundAfter) > len(filledAroundBefore)
+	return starts
 }
 
 func (f *FloodFiller) flood(base, start Coord, countSteps bool) Coords {
+	if countSteps {
+		f.floodDistance(base, start)
+		return Coords{}
+	}
+
 	// Flood base coord so that the flood cannot escape.
 	f.s.Fill(base)
 
 	filled := &Coords{}
-	if countSteps {
-		f.exploreDistance(start, start.GetDirectionsTo(base)[0], 0)
-	} else {
-		f.explore(start, start.GetDirectionsTo(base)[0], filled)
-	}
+	comingFromDirection, _ := f.s.getDirectionTo(start, base)
+	f.explore(f.s.Wrap(start), comingFromDirection, filled)
 
 	f.s.Remove(base)
 	return *filled
@@ -88,44 +183,101 @@ func (f *FloodFiller) explore(
 	comingFromDirection Direction,
 	filled *Coords,
 ) {
-	if f.s.IsFilled(target) {
+	if f.isBlocked(target) {
 		return
 	}
 	f.s.Fill(target)
 	*filled = append(*filled, target)
-	for _, d := range GetAllDirections() {
+	for _, d := range f.s.getDirections() {
 		if d == comingFromDirection {
 			continue
 		}
-		f.explore(target.GetCoordInDirection(d), d.Opposite(), filled)
+		f.explore(f.s.getCoordInDirection(target, d), d.Opposite(), filled)
 	}
 }
 
-// exploreDistance is like explore but also counts the number of steps it takes to reach positions.
-// It is a bit more inefficient because it will re-explore coords that were already reached,
-// but that can be explored by a different call/routine in fewer steps.
-func (f *FloodFiller) exploreDistance(
-	target Coord,
-	comingFromDirection Direction,
-	numStepsTaken int,
-) {
-	numStepsTaken++
-	if !f.s.Fits(target) {
-		return
+// floodDistance floods the surface breadth-first from `base`, starting the flood at the given coords, and saves for
+// each coord that is reached the number of steps it takes to reach it. Distances of earlier floods are cleared.
+// See getDistances.
+func (f *FloodFiller) floodDistance(base Coord, starts ...Coord) {
+	f.s.clearDistances()
+	for i, distance := range f.getDistances(base, starts...) {
+		f.s.cells[i].distance = distance
 	}
-	curVal, exists := f.s.getValue(target)
-	if !exists || curVal.distance == 0 || curVal.distance > numStepsTaken {
-		f.s.setDistance(target, numStepsTaken)
-	} else if exists && curVal.distance <= numStepsTaken {
-		return
+}
+
+// getDistances floods the surface breadth-first from `base`, starting the flood at the given coords, and returns for
+// each coord the number of steps it takes to reach it, indexed like the cells of the surface. Coords that are not
+// reached have distance 0. Filled coords are given a distance too, but the flood does not continue past them.
+// Because the flood is breadth-first, the first time a coord is reached is also the quickest way to reach it, so each
+// coord is visited only once. The flood does not enter `base` and does not give it a distance.
+// The surface is not changed.
+func (f *FloodFiller) getDistances(base Coord, starts ...Coord) []int {
+	return f.floodDistances(base, starts, false)
+}
+
+// getDistancesOverTime does the same as getDistances, but treats coords that are filled until a given step (see
+// Surface.FillUntil) as free if they are reached at or after that step. Coords that are filled when they are reached
+// are not given a distance, so that they can still be reached later, when they are free.
+func (f *FloodFiller) getDistancesOverTime(base Coord, starts ...Coord) []int {
+	return f.floodDistances(base, starts, true)
+}
+
+func (f *FloodFiller) floodDistances(base Coord, starts Coords, overTime bool) []int {
+	isFilled := func(c Coord, step int) bool {
+		if overTime {
+			return f.s.IsFilledAt(c, step) || f.blocksKind(f.s.GetCell(c).Kind)
+		}
+		return f.isBlocked(c)
 	}
-	if f.s.IsFilled(target) {
-		return
+
+	distances := make([]int, f.s.TotalSurface())
+	base = f.s.Wrap(base)
+	if f.s.Fits(base) {
+		// Mark base as visited, so that the flood does not enter it.
+		distances[f.s.index(base)] = -1
 	}
-	for _, d := range GetAllDirections() {
-		if d == comingFromDirection {
+
+	queue := make(Coords, 0, len(starts))
+	for _, start := range starts {
+		if !f.s.Fits(start) || distances[f.s.index(start)] != 0 || (overTime && isFilled(start, 1)) {
 			continue
 		}
-		f.exploreDistance(target.GetCoordInDirection(d), d.Opposite(), numStepsTaken)
+		distances[f.s.index(start)] = 1
+		if !isFilled(start, 1) {
+			queue = append(queue, start)
+		}
+	}
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		numSteps := distances[f.s.index(cur)] + 1
+		for _, c := range f.s.GetCoordsAround(cur) {
+			if !f.s.Fits(c) || distances[f.s.index(c)] != 0 || (overTime && isFilled(c, numSteps)) {
+				continue
+			}
+			distances[f.s.index(c)] = numSteps
+			if !isFilled(c, numSteps) {
+				queue = append(queue, c)
+			}
+		}
+	}
+	if f.s.Fits(base) {
+		distances[f.s.index(base)] = 0
+	}
+	return distances
+}
+
+// isBlocked returns true if the flood cannot pass the given coord, because it is filled or of a blocking kind.
+func (f *FloodFiller) isBlocked(c Coord) bool {
+	return f.s.IsFilled(c) || f.blocksKind(f.s.GetCell(c).Kind)
+}
+
+// blocksKind returns true if the flood cannot pass cells of the given kind.
+func (f *FloodFiller) blocksKind(kind CellKind) bool {
+	for _, blockingKind := range f.blockingKinds {
+		if kind == blockingKind {
+			return true
+		}
 	}
+	return false
 }
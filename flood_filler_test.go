@@ -349,6 +349,22 @@ func Test_FloodFiller_CountSteps(t *testing.T) {
 			})
 		})
 
+		Convey("On a large surface", func() {
+			s := NewSurface(100, 100)
+			filler := NewFloodFiller(s)
+
+			Convey("Returns the number of steps", func() {
+				So(filler.CountSteps(Coord{0, 0}, Coord{99, 99}), ShouldEqual, 198)
+			})
+		})
+
+		Convey("When counting twice on the same surface", func() {
+			Convey("Returns the same number of steps", func() {
+				So(filler.CountSteps(base, target), ShouldEqual, 8)
+				So(filler.CountSteps(base, target), ShouldEqual, 8)
+			})
+		})
+
 		Convey("If target is next to starting point", func() {
 			// . . .
 			// . . .
@@ -379,6 +395,89 @@ func Test_FloodFiller_CountSteps(t *testing.T) {
 	})
 }
 
+func Test_FloodFiller_ShortestPath(t *testing.T) {
+	Convey("FloodFiller.ShortestPath()", t, func() {
+		s := NewSurface(5, 5)
+		filler := NewFloodFiller(s)
+
+		Convey("Without obstacles", func() {
+			Convey("Returns a path with the smallest number of steps", func() {
+				path, ok := filler.ShortestPath(Coord{0, 0}, Coord{4, 4})
+
+				So(ok, ShouldBeTrue)
+				So(path, ShouldHaveLength, 8)
+				So(path[len(path)-1], ShouldResemble, Coord{4, 4})
+				So(path[0].ConnectsTo(Coord{0, 0}), ShouldBeTrue)
+				for i := 1; i < len(path); i++ {
+					So(path[i].ConnectsTo(path[i-1]), ShouldBeTrue)
+				}
+			})
+		})
+
+		Convey("When target is around an obstacle", func() {
+			// (S = start, T = target, x = filled)
+			// . . . . .
+			// . . . . .
+			// x x x x .
+			// . . . . .
+			// S . . . T
+			s.fillRows([][]int{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{1, 1, 1, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+			})
+
+			Convey("Returns the path around the obstacle", func() {
+				path, ok := filler.ShortestPath(Coord{0, 0}, Coord{0, 4})
+
+				So(ok, ShouldBeTrue)
+				So(path, ShouldHaveLength, 12)
+				So(path[:8].Contains(Coord{4, 2}), ShouldBeTrue)
+				So(path[len(path)-1], ShouldResemble, Coord{0, 4})
+				for i, c := range path {
+					So(s.IsFilled(c), ShouldBeFalse)
+					if i > 0 {
+						So(c.ConnectsTo(path[i-1]), ShouldBeTrue)
+					}
+				}
+			})
+
+			Convey("If cannot make path", func() {
+				s.Fill(Coord{4, 2})
+
+				Convey("Returns false", func() {
+					path, ok := filler.ShortestPath(Coord{0, 0}, Coord{0, 4})
+
+					So(ok, ShouldBeFalse)
+					So(path, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("If target is filled", func() {
+			s.Fill(Coord{2, 0})
+
+			Convey("Returns the path ending on the target", func() {
+				path, ok := filler.ShortestPath(Coord{0, 0}, Coord{2, 0})
+
+				So(ok, ShouldBeTrue)
+				So(path, ShouldResemble, Coords{{1, 0}, {2, 0}})
+			})
+		})
+
+		Convey("If target equals base", func() {
+			Convey("Returns an empty path", func() {
+				path, ok := filler.ShortestPath(Coord{0, 0}, Coord{0, 0})
+
+				So(ok, ShouldBeTrue)
+				So(path, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func Test_FloodFiller_flood(t *testing.T) {
 	Convey("FloodFiller.flood()", t, func() {
 		s := NewSurface(3, 3)
@@ -391,21 +490,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				So(filled, ShouldResemble, Coords{})
 
 				// Bottom row
-				v, ok := s.surface[0][0]
+				v, ok := s.getValue(Coord{0, 0})
 				So(ok, ShouldBeFalse)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 0,
 				})
 
-				v, ok = s.surface[1][0]
+				v, ok = s.getValue(Coord{1, 0})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 1,
 				})
 
-				v, ok = s.surface[2][0]
+				v, ok = s.getValue(Coord{2, 0})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -413,21 +512,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				})
 
 				// Middle row
-				v, ok = s.surface[0][1]
+				v, ok = s.getValue(Coord{0, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 3,
 				})
 
-				v, ok = s.surface[1][1]
+				v, ok = s.getValue(Coord{1, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 2,
 				})
 
-				v, ok = s.surface[2][1]
+				v, ok = s.getValue(Coord{2, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -435,21 +534,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				})
 
 				// Top row
-				v, ok = s.surface[0][2]
+				v, ok = s.getValue(Coord{0, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 4,
 				})
 
-				v, ok = s.surface[1][2]
+				v, ok = s.getValue(Coord{1, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 3,
 				})
 
-				v, ok = s.surface[2][2]
+				v, ok = s.getValue(Coord{2, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -463,21 +562,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				filler.flood(Coord{0, 0}, Coord{1, 0}, true)
 
 				// Bottom row
-				v, ok := s.surface[0][0]
+				v, ok := s.getValue(Coord{0, 0})
 				So(ok, ShouldBeFalse)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 0,
 				})
 
-				v, ok = s.surface[1][0]
+				v, ok = s.getValue(Coord{1, 0})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 1,
 				})
 
-				v, ok = s.surface[2][0]
+				v, ok = s.getValue(Coord{2, 0})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -485,21 +584,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				})
 
 				// Middle row
-				v, ok = s.surface[0][1]
+				v, ok = s.getValue(Coord{0, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: true,
 					distance: 3,
 				})
 
-				v, ok = s.surface[1][1]
+				v, ok = s.getValue(Coord{1, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 2,
 				})
 
-				v, ok = s.surface[2][1]
+				v, ok = s.getValue(Coord{2, 1})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -507,21 +606,21 @@ func Test_FloodFiller_flood(t *testing.T) {
 				})
 
 				// Top row
-				v, ok = s.surface[0][2]
+				v, ok = s.getValue(Coord{0, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 4,
 				})
 
-				v, ok = s.surface[1][2]
+				v, ok = s.getValue(Coord{1, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
 					distance: 3,
 				})
 
-				v, ok = s.surface[2][2]
+				v, ok = s.getValue(Coord{2, 2})
 				So(ok, ShouldBeTrue)
 				So(v, ShouldResemble, coordVal{
 					isFilled: false,
@@ -531,3 +630,293 @@ func Test_FloodFiller_flood(t *testing.T) {
 		})
 	})
 }
+
+func Test_FloodFiller_ReadOnly(t *testing.T) {
+	Convey("FloodFiller read only methods", t, func() {
+		// (S = start, x = filled)
+		// . . . . .
+		// . . . x .
+		// . . . x .
+		// . x . x .
+		// S x . . .
+		s := NewSurface(5, 5)
+		s.fillRows([][]int{
+			{0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0},
+			{0, 0, 0, 1, 0},
+			{0, 1, 0, 1, 0},
+			{0, 1, 0, 0, 0},
+		})
+		base := Coord{0, 0}
+		filler := NewFloodFiller(s)
+		before := s.Clone()
+
+		Convey("FloodReadOnly()", func() {
+			Convey("Returns the same coords as Flood", func() {
+				filled := filler.FloodReadOnly(base, Coord{0, 1})
+				expected := NewFloodFiller(s.Clone()).Flood(base, Coord{0, 1})
+
+				So(filled, ShouldHaveLength, 19)
+				So(filled.Equals(expected), ShouldBeTrue)
+				So(s, ShouldResemble, before)
+			})
+
+			Convey("Returns nothing if starting at a filled coord", func() {
+				So(filler.FloodReadOnly(base, Coord{1, 0}), ShouldBeEmpty)
+			})
+
+			Convey("Returns nothing if start does not connect to base", func() {
+				So(filler.FloodReadOnly(base, Coord{2, 2}), ShouldBeEmpty)
+			})
+
+			Convey("Starts across the edges of wrapped surfaces", func() {
+				wrapped := NewWrappedSurface(5, 5)
+
+				filled := NewFloodFiller(wrapped).FloodReadOnly(base, Coord{-1, 0})
+				expected := NewFloodFiller(wrapped.Clone()).Flood(base, Coord{-1, 0})
+
+				So(filled, ShouldHaveLength, 24)
+				So(filled.Equals(expected), ShouldBeTrue)
+			})
+		})
+
+		Convey("CountStepsReadOnly()", func() {
+			Convey("Returns the same number of steps as CountSteps", func() {
+				for _, target := range []Coord{{4, 4}, {2, 0}, {4, 0}, {1, 0}} {
+					expected := NewFloodFiller(s.Clone()).CountSteps(base, target)
+
+					So(filler.CountStepsReadOnly(base, target), ShouldEqual, expected)
+				}
+				So(s, ShouldResemble, before)
+			})
+
+			Convey("Returns -1 if target cannot be reached", func() {
+				s.Fill(Coord{0, 1})
+
+				So(filler.CountStepsReadOnly(base, Coord{4, 4}), ShouldEqual, -1)
+			})
+
+			Convey("Returns -1 if target does not fit", func() {
+				So(filler.CountStepsReadOnly(base, Coord{5, 5}), ShouldEqual, -1)
+			})
+		})
+
+		Convey("CanReachReadOnly()", func() {
+			Convey("Returns true if target can be reached", func() {
+				So(filler.CanReachReadOnly(base, Coord{4, 0}), ShouldBeTrue)
+				So(s, ShouldResemble, before)
+			})
+
+			Convey("Returns false if target cannot be reached", func() {
+				s.Fill(Coord{0, 1})
+
+				So(filler.CanReachReadOnly(base, Coord{4, 0}), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func Test_FloodFiller_Wrapped(t *testing.T) {
+	Convey("FloodFiller on a wrapped surface", t, func() {
+		// (S = start, T = target, x = filled)
+		// . . x . .
+		// . . x . .
+		// S . x . T
+		s := NewWrappedSurface(5, 3)
+		s.fillRows([][]int{
+			{0, 0, 1, 0, 0},
+			{0, 0, 1, 0, 0},
+			{0, 0, 1, 0, 0},
+		})
+		base := Coord{0, 0}
+		target := Coord{4, 0}
+
+		Convey("CountSteps() steps across the edge", func() {
+			So(NewFloodFiller(s).CountSteps(base, target), ShouldEqual, 1)
+			So(NewFloodFiller(s).CountStepsReadOnly(base, target), ShouldEqual, 1)
+		})
+
+		Convey("CanReach() reaches across the edge", func() {
+			So(NewFloodFiller(s.Clone()).CanReach(base, target), ShouldBeTrue)
+			So(NewFloodFiller(s).CanReachReadOnly(base, target), ShouldBeTrue)
+		})
+
+		Convey("Flood() can start across the edge", func() {
+			filled := NewFloodFiller(s.Clone()).Flood(base, target)
+
+			So(filled, ShouldHaveLength, 11)
+			So(filled.Equals(NewFloodFiller(s).FloodReadOnly(base, target)), ShouldBeTrue)
+		})
+
+		Convey("ShortestPath() steps across the edge", func() {
+			path, ok := NewFloodFiller(s).ShortestPath(base, Coord{3, 1})
+
+			So(ok, ShouldBeTrue)
+			So(path, ShouldHaveLength, 3)
+			So(path[0].Equals(target) || path[0].Equals(Coord{0, 1}), ShouldBeTrue)
+			So(path[2], ShouldResemble, Coord{3, 1})
+		})
+
+		Convey("If not wrapped, the edge is a wall", func() {
+			notWrapped := NewSurface(5, 3)
+			notWrapped.Fill(s.GetFilled()...)
+
+			So(NewFloodFiller(notWrapped).CountSteps(base, target), ShouldEqual, -1)
+		})
+	})
+}
+
+func Test_FloodFiller_EightConnected(t *testing.T) {
+	Convey("FloodFiller on an eight connected surface", t, func() {
+		// (S = start, T = target, x = filled)
+		// . x T
+		// x . x
+		// S x .
+		s := NewSurface(3, 3)
+		s.SetConnectivity(EightConnected)
+		s.fillRows([][]int{
+			{0, 1, 0},
+			{1, 0, 1},
+			{0, 1, 0},
+		})
+		base := Coord{0, 0}
+		target := Coord{2, 2}
+
+		Convey("CountSteps() steps diagonally", func() {
+			So(NewFloodFiller(s.Clone()).CountSteps(base, target), ShouldEqual, 2)
+			So(NewFloodFiller(s).CountStepsReadOnly(base, target), ShouldEqual, 2)
+		})
+
+		Convey("ShortestPath() steps diagonally", func() {
+			path, ok := NewFloodFiller(s.Clone()).ShortestPath(base, target)
+
+			So(ok, ShouldBeTrue)
+			So(path, ShouldResemble, Coords{{1, 1}, {2, 2}})
+		})
+
+		Convey("Flood() floods diagonally", func() {
+			filled := NewFloodFiller(s.Clone()).Flood(base, Coord{1, 1})
+
+			So(filled, ShouldHaveLength, 4)
+			So(filled.Equals(NewFloodFiller(s).FloodReadOnly(base, Coord{1, 1})), ShouldBeTrue)
+		})
+
+		Convey("CanReach() reaches diagonally", func() {
+			So(NewFloodFiller(s.Clone()).CanReach(base, target), ShouldBeTrue)
+		})
+
+		Convey("If four connected, the target cannot be reached", func() {
+			s.SetConnectivity(FourConnected)
+
+			So(NewFloodFiller(s).CountSteps(base, target), ShouldEqual, -1)
+		})
+	})
+}
+
+func Test_FloodFiller_CountStepsOverTime(t *testing.T) {
+	Convey("FloodFiller.CountStepsOverTime()", t, func() {
+		// (S = start, T = target, numbers = filled until that step)
+		// . 3 T
+		// . 2 .
+		// S 1 .
+		s := NewSurface(3, 3)
+		s.FillUntil(1, Coord{1, 0})
+		s.FillUntil(2, Coord{1, 1})
+		s.FillUntil(3, Coord{1, 2})
+		filler := NewFloodFiller(s)
+		base := Coord{0, 0}
+
+		Convey("Passes coords that are free by the time they are reached", func() {
+			So(filler.CountStepsOverTime(base, Coord{2, 0}), ShouldEqual, 2)
+			So(filler.CountStepsOverTime(base, Coord{2, 2}), ShouldEqual, 4)
+			So(filler.CountStepsOverTime(base, Coord{1, 2}), ShouldEqual, 3)
+		})
+
+		Convey("Reaches coords later if they are not free yet the first time they are reached", func() {
+			// . . .
+			// S 3 .
+			s := NewSurface(3, 2)
+			s.FillUntil(3, Coord{1, 0})
+
+			So(NewFloodFiller(s).CountStepsOverTime(base, Coord{1, 0}), ShouldEqual, 3)
+		})
+
+		Convey("Does not change the surface", func() {
+			before := s.Clone()
+
+			filler.CountStepsOverTime(base, Coord{2, 2})
+
+			So(s, ShouldResemble, before)
+		})
+
+		Convey("Treats the coords as filled forever in CountSteps", func() {
+			So(NewFloodFiller(s.Clone()).CountSteps(base, Coord{2, 2}), ShouldEqual, -1)
+		})
+	})
+}
+
+func Test_FloodFiller_SetBlockingKinds(t *testing.T) {
+	Convey("FloodFiller.SetBlockingKinds()", t, func() {
+		// (S = start, T = target, b = body, h = hazard)
+		// . . b . .
+		// . . b . .
+		// S . h . T
+		s := NewSurface(5, 3)
+		s.SetCell(Cell{Kind: CellBody, Owner: 1}, Coord{2, 1}, Coord{2, 2})
+		s.SetCell(Cell{Kind: CellHazard}, Coord{2, 0})
+		base := Coord{0, 0}
+		target := Coord{4, 0}
+
+		Convey("By default, only filled coords block the flood", func() {
+			So(NewFloodFiller(s).CountSteps(base, target), ShouldEqual, 4)
+		})
+
+		Convey("Blocks the flood at cells of the given kinds", func() {
+			filler := NewFloodFiller(s)
+			filler.SetBlockingKinds(CellBody)
+
+			So(filler.CountSteps(base, target), ShouldEqual, 4)
+			So(filler.CountStepsReadOnly(base, target), ShouldEqual, 4)
+
+			filler.SetBlockingKinds(CellBody, CellHazard)
+
+			So(filler.CountSteps(base, target), ShouldEqual, -1)
+			So(filler.CountStepsReadOnly(base, target), ShouldEqual, -1)
+			So(filler.CanReachReadOnly(base, target), ShouldBeFalse)
+			So(filler.FloodReadOnly(base, Coord{1, 0}), ShouldHaveLength, 5)
+			_, ok := filler.ShortestPath(base, target)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Flood() does not fill cells of the given kinds", func() {
+			filler := NewFloodFiller(s)
+			filler.SetBlockingKinds(CellBody, CellHazard)
+
+			So(filler.Flood(base, Coord{1, 0}), ShouldHaveLength, 5)
+			So(s.CellsOfKind(CellBody).Equals(Coords{{2, 1}, {2, 2}}), ShouldBeTrue)
+			So(s.IsFilled(Coord{2, 0}), ShouldBeFalse)
+			So(filler.CanReach(base, target), ShouldBeFalse)
+		})
+
+		Convey("GetTerritories() does not claim cells of the given kinds", func() {
+			filler := NewFloodFiller(s)
+			filler.SetBlockingKinds(CellBody, CellHazard)
+
+			territories := filler.GetTerritories(base, target)
+
+			So(territories[0].Size(), ShouldEqual, 5)
+			So(territories[1].Size(), ShouldEqual, 5)
+		})
+
+		Convey("Over time, blocks cells of the given kinds at every step", func() {
+			filler := NewFloodFiller(s)
+			filler.SetBlockingKinds(CellHazard)
+
+			So(filler.CountStepsOverTime(base, target), ShouldEqual, 6)
+
+			filler.SetBlockingKinds(CellBody, CellHazard)
+
+			So(filler.CountStepsOverTime(base, target), ShouldEqual, -1)
+		})
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/minitauros/go-plane
 
-go 1.18
+go 1.23
 
 require github.com/smartystreets/goconvey v1.7.2
 
@@ -0,0 +1,70 @@
+package plane
+
+import "iter"
+
+// All returns an iterator over all coords of the surface and whether they are filled, sorted by x, then by y.
+func (s *Surface) All() iter.Seq2[Coord, bool] {
+	return func(yield func(Coord, bool) bool) {
+		for x := 0; x < s.width; x++ {
+			for y := 0; y < s.height; y++ {
+				if !yield(Coord{x, y}, s.cells[y*s.width+x].isFilled) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Filled returns an iterator over the filled coords, sorted by x, then by y.
+func (s *Surface) Filled() iter.Seq[Coord] {
+	return s.coordsWhere(true)
+}
+
+// Unfilled returns an iterator over the unfilled coords, sorted by x, then by y.
+func (s *Surface) Unfilled() iter.Seq[Coord] {
+	return s.coordsWhere(false)
+}
+
+// InRect returns an iterator over the coords in the rectangle between the given corners, including the corners, sorted
+// by x, then by y. Coords that do not fit on the surface are left out.
+func (s *Surface) InRect(min, max Coord) iter.Seq[Coord] {
+	return func(yield func(Coord) bool) {
+		for x := maxInt(min.X, 0); x <= minInt(max.X, s.width-1); x++ {
+			for y := maxInt(min.Y, 0); y <= minInt(max.Y, s.height-1); y++ {
+				if !yield(Coord{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbours returns an iterator over the coords that are next to the given coord, taking into account the
+// connectivity of the surface. On wrapped surfaces, the coords are wrapped. Coords that do not fit on the surface are
+// left out. See GetCoordsAround.
+func (s *Surface) Neighbours(coord Coord) iter.Seq[Coord] {
+	return func(yield func(Coord) bool) {
+		for _, c := range s.GetCoordsAround(coord) {
+			if !s.Fits(c) {
+				continue
+			}
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// coordsWhere returns an iterator over the coords that are filled or not, sorted by x, then by y.
+func (s *Surface) coordsWhere(filled bool) iter.Seq[Coord] {
+	return func(yield func(Coord) bool) {
+		for coord, isFilled := range s.All() {
+			if isFilled != filled {
+				continue
+			}
+			if !yield(coord) {
+				return
+			}
+		}
+	}
+}
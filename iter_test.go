@@ -0,0 +1,110 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Surface_Iterators(t *testing.T) {
+	Convey("Surface iterators", t, func() {
+		// . x .
+		// x . .
+		s := NewSurface(3, 2)
+		s.Fill(Coord{0, 0}, Coord{1, 1})
+
+		Convey("All() iterates over all coords, sorted by x, then by y", func() {
+			var coords Coords
+			var filled []bool
+			for coord, isFilled := range s.All() {
+				coords = append(coords, coord)
+				filled = append(filled, isFilled)
+			}
+
+			So(coords, ShouldResemble, Coords{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}})
+			So(filled, ShouldResemble, []bool{true, false, false, true, false, false})
+		})
+
+		Convey("Filled() iterates over the filled coords", func() {
+			var coords Coords
+			for coord := range s.Filled() {
+				coords = append(coords, coord)
+			}
+
+			So(coords, ShouldResemble, Coords{{0, 0}, {1, 1}})
+		})
+
+		Convey("Unfilled() iterates over the unfilled coords", func() {
+			var coords Coords
+			for coord := range s.Unfilled() {
+				coords = append(coords, coord)
+			}
+
+			So(coords, ShouldResemble, Coords{{0, 1}, {1, 0}, {2, 0}, {2, 1}})
+		})
+
+		Convey("InRect() iterates over the coords in the rectangle that fit", func() {
+			var coords Coords
+			for coord := range s.InRect(Coord{1, -1}, Coord{5, 0}) {
+				coords = append(coords, coord)
+			}
+
+			So(coords, ShouldResemble, Coords{{1, 0}, {2, 0}})
+		})
+
+		Convey("Neighbours() iterates over the coords next to a coord that fit", func() {
+			var coords Coords
+			for coord := range s.Neighbours(Coord{0, 0}) {
+				coords = append(coords, coord)
+			}
+
+			So(coords.Equals(Coords{{0, 1}, {1, 0}}), ShouldBeTrue)
+		})
+
+		Convey("Neighbours() wraps and takes the connectivity into account", func() {
+			wrapped := NewWrappedSurface(3, 3)
+			wrapped.SetConnectivity(EightConnected)
+
+			var coords Coords
+			for coord := range wrapped.Neighbours(Coord{0, 0}) {
+				coords = append(coords, coord)
+			}
+
+			So(coords, ShouldHaveLength, 8)
+			So(coords.Contains(Coord{2, 2}), ShouldBeTrue)
+		})
+
+		Convey("Can be left early", func() {
+			var num int
+			for range s.All() {
+				num++
+				if num == 2 {
+					break
+				}
+			}
+			for range s.Unfilled() {
+				num++
+				break
+			}
+			for range s.InRect(Coord{0, 0}, Coord{2, 1}) {
+				num++
+				break
+			}
+			for range s.Neighbours(Coord{1, 1}) {
+				num++
+				break
+			}
+
+			So(num, ShouldEqual, 5)
+		})
+
+		Convey("EachFilled() still returns the filled coords", func() {
+			var coords Coords
+			for coord := range s.EachFilled() {
+				coords = append(coords, coord)
+			}
+
+			So(coords, ShouldResemble, Coords{{0, 0}, {1, 1}})
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package plane
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Glyph describes the cell that a character in a rendered surface stands for.
+type Glyph struct {
+	Filled bool
+	// Cost is the cost of moving onto the cell. Zero means the default cost of 1.
+	Cost int
+	// Cell is what is at the coord. See Surface.SetCell.
+	Cell Cell
+}
+
+// DefaultGlyphs are the characters that GetRender draws cells with.
+var DefaultGlyphs = map[rune]Glyph{
+	'.': {},
+	'x': {Filled: true},
+}
+
+// rowLegendRegexp matches a row of a rendered surface that starts with its y legend and vertical axis.
+var rowLegendRegexp = regexp.MustCompile(`^\s*\d+ ([|~])(.*)$`)
+
+// ParseSurface parses a surface as rendered by GetRender, with or without the axes and their legends.
+// Cells may or may not be separated by spaces. If the axes are drawn with tildes, the surface is wrapped.
+func ParseSurface(render string) (*Surface, error) {
+	return ParseSurfaceWithGlyphs(render, nil)
+}
+
+// ParseSurfaceWithGlyphs is like ParseSurface, but also accepts the given glyphs. They are added to DefaultGlyphs,
+// overriding them if a character is in both.
+func ParseSurfaceWithGlyphs(render string, glyphs map[rune]Glyph) (*Surface, error) {
+	allGlyphs := make(map[rune]Glyph, len(DefaultGlyphs)+len(glyphs))
+	for r, g := range DefaultGlyphs {
+		allGlyphs[r] = g
+	}
+	for r, g := range glyphs {
+		allGlyphs[r] = g
+	}
+
+	var rows [][]Glyph
+	var wrapped bool
+	for _, line := range strings.Split(render, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if isAxis(trimmed) {
+			// Only the x legend follows the horizontal axis.
+			wrapped = wrapped || trimmed[0] == '~'
+			break
+		}
+
+		if match := rowLegendRegexp.FindStringSubmatch(line); match != nil {
+			wrapped = wrapped || match[1] == "~"
+			trimmed = match[2]
+		}
+
+		var row []Glyph
+		for _, r := range trimmed {
+			if r == ' ' || r == '\t' {
+				continue
+			}
+			g, ok := allGlyphs[r]
+			if !ok {
+				return nil, fmt.Errorf("could not parse surface: unknown glyph %q in row %d", r, len(rows))
+			}
+			row = append(row, g)
+		}
+		if len(rows) > 0 && len(row) != len(rows[0]) {
+			return nil, fmt.Errorf(
+				"could not parse surface: row %d has %d cells, expected %d", len(rows), len(row), len(rows[0]),
+			)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("could not parse surface: no rows found")
+	}
+
+	s := NewSurface(len(rows[0]), len(rows))
+	s.wrapped = wrapped
+	for i, row := range rows {
+		// The top row is rendered first.
+		y := len(rows) - i - 1
+		for x, g := range row {
+			coord := Coord{x, y}
+			if g.Filled {
+				s.Fill(coord)
+			}
+			if g.Cost != 0 {
+				s.SetCost(g.Cost, coord)
+			}
+			if g.Cell != (Cell{}) {
+				s.SetCell(g.Cell, coord)
+			}
+		}
+	}
+	return s, nil
+}
+
+// isAxis returns true if the given line is a horizontal axis as drawn by GetRender.
+func isAxis(line string) bool {
+	return strings.Trim(line, "-") == "" || strings.Trim(line, "~") == ""
+}
@@ -0,0 +1,117 @@
+package plane
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_ParseSurface(t *testing.T) {
+	Convey("ParseSurface()", t, func() {
+		Convey("Round-trips GetRender()", func() {
+			small := NewSurface(3, 2)
+			small.Fill(Coord{0, 0}, Coord{2, 1})
+			wide := NewSurface(12, 11)
+			wide.Fill(Coord{0, 0}, Coord{11, 10}, Coord{5, 3})
+			wrapped := NewWrappedSurface(4, 4)
+			wrapped.Fill(Coord{1, 2})
+
+			for i, s := range []*Surface{small, wide, wrapped, NewSurface(1, 1)} {
+				Convey(fmt.Sprintf("%d: %dx%d", i, s.width, s.height), func() {
+					parsed, err := ParseSurface(GetRender(s))
+
+					So(err, ShouldBeNil)
+					So(parsed, ShouldResemble, s)
+				})
+			}
+		})
+
+		Convey("Parses surfaces without axes", func() {
+			s, err := ParseSurface(`
+				. . x
+				x . .
+			`)
+
+			So(err, ShouldBeNil)
+			So(s.width, ShouldEqual, 3)
+			So(s.height, ShouldEqual, 2)
+			So(s.IsWrapped(), ShouldBeFalse)
+			So(s.GetFilled(), ShouldResemble, Coords{{0, 0}, {2, 1}})
+		})
+
+		Convey("Parses cells that are not separated by spaces", func() {
+			s, err := ParseSurface("..x\nx..")
+
+			So(err, ShouldBeNil)
+			So(s.GetFilled(), ShouldResemble, Coords{{0, 0}, {2, 1}})
+		})
+
+		Convey("Wraps surfaces of which the axes are drawn with tildes", func() {
+			s, err := ParseSurface("01 ~ . x\n00 ~ . .")
+
+			So(err, ShouldBeNil)
+			So(s.IsWrapped(), ShouldBeTrue)
+		})
+
+		Convey("Returns an error", func() {
+			testCases := []struct {
+				description string
+				render      string
+			}{
+				{"if there are no rows", "\n  \n"},
+				{"if a glyph is unknown", ". . .\n. o ."},
+				{"if rows differ in length", ". . .\n. ."},
+			}
+
+			for i, tc := range testCases {
+				Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
+					s, err := ParseSurface(tc.render)
+
+					So(err, ShouldNotBeNil)
+					So(s, ShouldBeNil)
+				})
+			}
+		})
+	})
+}
+
+func Test_ParseSurfaceWithGlyphs(t *testing.T) {
+	Convey("ParseSurfaceWithGlyphs()", t, func() {
+		Convey("Accepts extra glyphs besides the default ones", func() {
+			s, err := ParseSurfaceWithGlyphs(`
+				# h .
+				# x h
+			`, map[rune]Glyph{
+				'#': {Filled: true},
+				'h': {Cost: 16},
+			})
+
+			So(err, ShouldBeNil)
+			So(s.GetFilled(), ShouldResemble, Coords{{0, 0}, {0, 1}, {1, 0}})
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 16)
+			So(s.GetCost(Coord{2, 0}), ShouldEqual, 16)
+			So(s.GetCost(Coord{2, 1}), ShouldEqual, 1)
+		})
+
+		Convey("Sets the cells of the given glyphs", func() {
+			s, err := ParseSurfaceWithGlyphs("a a . f", map[rune]Glyph{
+				'a': {Filled: true, Cell: Cell{Kind: CellBody, Owner: 1}},
+				'f': {Cell: Cell{Kind: CellFood}},
+			})
+
+			So(err, ShouldBeNil)
+			So(s.CellsOfOwner(CellBody, 1), ShouldResemble, Coords{{0, 0}, {1, 0}})
+			So(s.CellsOfKind(CellFood), ShouldResemble, Coords{{3, 0}})
+			So(s.GetCell(Coord{2, 0}), ShouldResemble, Cell{})
+		})
+
+		Convey("Lets the given glyphs override the default ones", func() {
+			s, err := ParseSurfaceWithGlyphs("x .", map[rune]Glyph{'x': {Cost: 5}})
+
+			So(err, ShouldBeNil)
+			So(s.CountFilled(), ShouldEqual, 0)
+			So(s.GetCost(Coord{0, 0}), ShouldEqual, 5)
+		})
+	})
+}
@@ -0,0 +1,160 @@
+package plane
+
+import (
+	"container/heap"
+)
+
+// Heuristic estimates the cost of moving from `from` to `to`.
+// For FindPath to find the cheapest path, the estimate must never be higher than the actual cost.
+type Heuristic func(from, to Coord) int
+
+// ManhattanDistance is a Heuristic that returns the number of horizontal and vertical steps between two coords.
+// As each step costs at least 1, it never overestimates the cost of a path.
+func ManhattanDistance(from, to Coord) int {
+	return from.ManhattanDistanceTo(to)
+}
+
+// PathFinder finds the cheapest paths on a surface, taking into account the cost of each coord.
+// Unlike FloodFiller, it does not change the state of the surface, so there is no need to clone the surface before
+// using it, and one surface can be used by multiple path finders at the same time.
+type PathFinder struct {
+	s         *Surface
+	heuristic Heuristic
+}
+
+// NewPathFinder returns a new path finder that uses Surface.CountStepsIgnoringObstacles as heuristic, which equals
+// ManhattanDistance, unless the surface is wrapped or EightConnected.
+func NewPathFinder(surface *Surface) *PathFinder {
+	return &PathFinder{
+		s:         surface,
+		heuristic: surface.CountStepsIgnoringObstacles,
+	}
+}
+
+// SetHeuristic sets the heuristic that is used to estimate the cost of reaching the target.
+// Passing nil resets the heuristic to Surface.CountStepsIgnoringObstacles.
+func (p *PathFinder) SetHeuristic(heuristic Heuristic) {
+	if heuristic == nil {
+		heuristic = p.s.CountStepsIgnoringObstacles
+	}
+	p.heuristic = heuristic
+}
+
+// FindPath returns the coords that make up the cheapest path from `base` to `target`, and the cost of that path,
+// using A* search. Filled coords are obstacles, except for `target`, which may be filled. Each step costs the cost of
+// the coord that is moved onto (see Surface.SetCost).
+// The path does not include `base`, but does include `target`, like FloodFiller.ShortestPath.
+// Returns false if no path can be made.
+func (p *PathFinder) FindPath(base, target Coord) (Coords, int, bool) {
+	return p.search(base, target, p.heuristic)
+}
+
+// CheapestPath returns the coords that make up the cheapest path from `base` to `target`, and the cost of that path,
+// using Dijkstra's algorithm. It ignores the heuristic of the path finder, so it always finds the cheapest path, even
+// if the heuristic overestimates costs. Otherwise it works like FindPath.
+func (p *PathFinder) CheapestPath(base, target Coord) (Coords, int, bool) {
+	return p.search(base, target, func(from, to Coord) int {
+		return 0
+	})
+}
+
+// search returns the cheapest path from `base` to `target`, using the given heuristic to decide which coords to visit
+// first. With a heuristic that always returns 0, the search is Dijkstra's algorithm.
+func (p *PathFinder) search(base, target Coord, heuristic Heuristic) (Coords, int, bool) {
+	base, target = p.s.Wrap(base), p.s.Wrap(target)
+	if base.Equals(target) {
+		return Coords{}, 0, true
+	}
+	if !p.s.Fits(base) || !p.s.Fits(target) {
+		return nil, 0, false
+	}
+
+	// costs holds for each visited coord the cost of the cheapest path to it found so far, plus one, so that the zero
+	// value means that the coord has not been visited yet.
+	costs := make([]int, p.s.TotalSurface())
+	// numSteps holds for each visited coord the number of steps of the cheapest path to it found so far.
+	numSteps := make([]int, p.s.TotalSurface())
+	cameFrom := make([]Coord, p.s.TotalSurface())
+	costs[p.s.index(base)] = 1
+
+	open := &pathNodeHeap{{coord: base, estimate: heuristic(base, target)}}
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(pathNode)
+		if cur.coord.Equals(target) {
+			return p.reconstructPath(cameFrom, target, numSteps[p.s.index(target)]), cur.cost, true
+		}
+		if cur.cost > costs[p.s.index(cur.coord)]-1 {
+			// A cheaper path to this coord was found after this node was queued.
+			continue
+		}
+		for _, c := range p.s.GetCoordsAround(cur.coord) {
+			if !p.s.Fits(c) || (p.s.IsFilled(c) && !c.Equals(target)) {
+				continue
+			}
+			cost := cur.cost + p.s.GetCost(c)
+			i := p.s.index(c)
+			if costs[i] != 0 && costs[i]-1 <= cost {
+				continue
+			}
+			costs[i] = cost + 1
+			numSteps[i] = numSteps[p.s.index(cur.coord)] + 1
+			cameFrom[i] = cur.coord
+			heap.Push(open, pathNode{
+				coord:    c,
+				cost:     cost,
+				estimate: cost + heuristic(c, target),
+			})
+		}
+	}
+	return nil, 0, false
+}
+
+// reconstructPath walks back from `target` and returns the path of the given number of steps that leads to it.
+func (p *PathFinder) reconstructPath(cameFrom []Coord, target Coord, numSteps int) Coords {
+	path := make(Coords, numSteps)
+	cur := target
+	for i := numSteps - 1; i >= 0; i-- {
+		path[i] = cur
+		cur = cameFrom[p.s.index(cur)]
+	}
+	return path
+}
+
+// pathNode is a coord that is queued to be visited by the path finder.
+type pathNode struct {
+	coord Coord
+	// cost is the cost of the path from base to the coord.
+	cost int
+	// estimate is the cost plus the estimated cost of reaching the target from the coord.
+	estimate int
+}
+
+// pathNodeHeap is a min-heap of path nodes, ordered by estimate.
+type pathNodeHeap []pathNode
+
+func (h pathNodeHeap) Len() int {
+	return len(h)
+}
+
+func (h pathNodeHeap) Less(i, j int) bool {
+	if h[i].estimate == h[j].estimate {
+		// Prefer the node that is furthest along, as it is probably closer to the target.
+		return h[i].cost > h[j].cost
+	}
+	return h[i].estimate < h[j].estimate
+}
+
+func (h pathNodeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *pathNodeHeap) Push(x interface{}) {
+	*h = append(*h, x.(pathNode))
+}
+
+func (h *pathNodeHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,238 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_PathFinder_FindPath(t *testing.T) {
+	Convey("PathFinder.FindPath()", t, func() {
+		s := NewSurface(5, 5)
+		finder := NewPathFinder(s)
+
+		Convey("Without obstacles", func() {
+			Convey("Returns a path with the smallest cost", func() {
+				path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{4, 4})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 8)
+				So(path, ShouldHaveLength, 8)
+				So(path[len(path)-1], ShouldResemble, Coord{4, 4})
+				So(path[0].ConnectsTo(Coord{0, 0}), ShouldBeTrue)
+				for i := 1; i < len(path); i++ {
+					So(path[i].ConnectsTo(path[i-1]), ShouldBeTrue)
+				}
+			})
+		})
+
+		Convey("When target is around an obstacle", func() {
+			// (S = start, T = target, x = filled)
+			// T . . . .
+			// . . . . .
+			// x x x x .
+			// . . . . .
+			// S . . . .
+			s.fillRows([][]int{
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{1, 1, 1, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+			})
+
+			Convey("Returns the path around the obstacle", func() {
+				path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{0, 4})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 12)
+				So(path, ShouldHaveLength, 12)
+				So(path.Contains(Coord{4, 2}), ShouldBeTrue)
+				So(path[len(path)-1], ShouldResemble, Coord{0, 4})
+				for i, c := range path {
+					So(s.IsFilled(c), ShouldBeFalse)
+					if i > 0 {
+						So(c.ConnectsTo(path[i-1]), ShouldBeTrue)
+					}
+				}
+			})
+
+			Convey("Does not change the surface", func() {
+				before := s.Clone()
+
+				finder.FindPath(Coord{0, 0}, Coord{0, 4})
+
+				So(s, ShouldResemble, before)
+			})
+
+			Convey("If cannot make path", func() {
+				s.Fill(Coord{4, 2})
+
+				Convey("Returns false", func() {
+					path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{0, 4})
+
+					So(ok, ShouldBeFalse)
+					So(cost, ShouldEqual, 0)
+					So(path, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("If target is filled", func() {
+			s.Fill(Coord{2, 0})
+
+			Convey("Returns the path ending on the target", func() {
+				path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{2, 0})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 2)
+				So(path, ShouldResemble, Coords{{1, 0}, {2, 0}})
+			})
+		})
+
+		Convey("If target does not fit", func() {
+			Convey("Returns false", func() {
+				_, _, ok := finder.FindPath(Coord{0, 0}, Coord{5, 0})
+
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("If target equals base", func() {
+			Convey("Returns an empty path", func() {
+				path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{0, 0})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 0)
+				So(path, ShouldBeEmpty)
+			})
+		})
+
+		Convey("With a custom heuristic", func() {
+			var numCalls int
+			finder.SetHeuristic(func(from, to Coord) int {
+				numCalls++
+				return 0
+			})
+
+			Convey("Uses the heuristic and still finds the cheapest path", func() {
+				path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{4, 4})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 8)
+				So(path, ShouldHaveLength, 8)
+				So(numCalls, ShouldBeGreaterThan, 0)
+			})
+		})
+	})
+}
+
+func Test_PathFinder_CheapestPath(t *testing.T) {
+	Convey("PathFinder.CheapestPath()", t, func() {
+		s := NewSurface(5, 3)
+		finder := NewPathFinder(s)
+
+		Convey("Without costly coords", func() {
+			Convey("Returns a path with the smallest number of steps", func() {
+				path, cost, ok := finder.CheapestPath(Coord{0, 0}, Coord{4, 0})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 4)
+				So(path, ShouldResemble, Coords{{1, 0}, {2, 0}, {3, 0}, {4, 0}})
+			})
+		})
+
+		Convey("With costly coords in the way", func() {
+			// (S = start, T = target, h = cost 5)
+			// . . . . .
+			// . . h . .
+			// S . h . T
+			s.SetCost(5, Coord{2, 0}, Coord{2, 1})
+
+			Convey("Returns the path around the costly coords", func() {
+				path, cost, ok := finder.CheapestPath(Coord{0, 0}, Coord{4, 0})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 8)
+				So(path, ShouldHaveLength, 8)
+				So(path.Contains(Coord{2, 2}), ShouldBeTrue)
+				So(path[len(path)-1], ShouldResemble, Coord{4, 0})
+			})
+
+			Convey("Finds the same cost as FindPath", func() {
+				_, cost, _ := finder.FindPath(Coord{0, 0}, Coord{4, 0})
+
+				So(cost, ShouldEqual, 8)
+			})
+
+			Convey("If going around is more expensive", func() {
+				s.Fill(Coord{2, 2})
+
+				Convey("Returns the path through the costly coords", func() {
+					path, cost, ok := finder.CheapestPath(Coord{0, 0}, Coord{4, 0})
+
+					So(ok, ShouldBeTrue)
+					So(cost, ShouldEqual, 8)
+					So(path, ShouldResemble, Coords{{1, 0}, {2, 0}, {3, 0}, {4, 0}})
+				})
+			})
+		})
+
+		Convey("If the heuristic overestimates", func() {
+			finder.SetHeuristic(func(from, to Coord) int {
+				return 100 * from.ManhattanDistanceTo(to)
+			})
+			s.SetCost(5, Coord{1, 0}, Coord{2, 0}, Coord{3, 0})
+
+			Convey("Still returns the cheapest path", func() {
+				_, cost, ok := finder.CheapestPath(Coord{0, 0}, Coord{4, 0})
+
+				So(ok, ShouldBeTrue)
+				So(cost, ShouldEqual, 6)
+			})
+		})
+
+		Convey("If cannot make path", func() {
+			s.Fill(Coord{2, 0}, Coord{2, 1}, Coord{2, 2})
+
+			Convey("Returns false", func() {
+				path, _, ok := finder.CheapestPath(Coord{0, 0}, Coord{4, 0})
+
+				So(ok, ShouldBeFalse)
+				So(path, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func Test_PathFinder_Wrapped(t *testing.T) {
+	Convey("PathFinder on a wrapped surface", t, func() {
+		s := NewWrappedSurface(5, 5)
+		finder := NewPathFinder(s)
+
+		Convey("Finds the path across the edges", func() {
+			path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{4, 4})
+
+			So(ok, ShouldBeTrue)
+			So(cost, ShouldEqual, 2)
+			So(path, ShouldHaveLength, 2)
+			So(path[1], ShouldResemble, Coord{4, 4})
+		})
+	})
+}
+
+func Test_PathFinder_EightConnected(t *testing.T) {
+	Convey("PathFinder on an eight connected surface", t, func() {
+		s := NewSurface(5, 5)
+		s.SetConnectivity(EightConnected)
+		finder := NewPathFinder(s)
+
+		Convey("Finds the diagonal path", func() {
+			path, cost, ok := finder.FindPath(Coord{0, 0}, Coord{4, 4})
+
+			So(ok, ShouldBeTrue)
+			So(cost, ShouldEqual, 4)
+			So(path, ShouldResemble, Coords{{1, 1}, {2, 2}, {3, 3}, {4, 4}})
+		})
+	})
+}
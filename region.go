@@ -0,0 +1,116 @@
+package plane
+
+// Region is a part of a surface of which all unfilled coords are connected to each other, and not to any other
+// unfilled coords.
+type Region struct {
+	// ID identifies the region. The IDs of the regions of a surface range from 0 up to, but not including, the number of
+	// regions.
+	ID int
+	// Coords are the coords that make up the region.
+	Coords Coords
+	// Min is the bottom left coord of the smallest rectangle that contains all coords of the region.
+	Min Coord
+	// Max is the top right coord of the smallest rectangle that contains all coords of the region.
+	Max Coord
+}
+
+// Size returns the number of coords in the region.
+func (r Region) Size() int {
+	return len(r.Coords)
+}
+
+// Regions are the regions of unfilled coords of a surface. It is a snapshot: changes to the surface after it was
+// created are not taken into account.
+type Regions struct {
+	s       *Surface
+	regions []Region
+	// ids holds the region ID of each coord, indexed like the cells of the surface. Filled coords have ID -1.
+	ids []int
+}
+
+// GetRegions labels all unfilled coords with the ID of the region they are part of, in a single pass.
+// It takes into account the connectivity of the surface and whether it is wrapped. On wrapped surfaces, the bounding
+// rectangle of a region that crosses the edges contains the coords on both sides of the surface, and can thus be much
+// larger than the region itself.
+func (s *Surface) GetRegions() *Regions {
+	r := &Regions{
+		s:   s,
+		ids: make([]int, s.TotalSurface()),
+	}
+	for i := range r.ids {
+		r.ids[i] = -1
+	}
+
+	var queue Coords
+	for i, cell := range s.cells {
+		if cell.isFilled || r.ids[i] != -1 {
+			continue
+		}
+		start := s.coordAt(i)
+		region := Region{
+			ID:  len(r.regions),
+			Min: start,
+			Max: start,
+		}
+		r.ids[i] = region.ID
+		queue = append(queue[:0], start)
+		for j := 0; j < len(queue); j++ {
+			cur := queue[j]
+			region.Coords = append(region.Coords, cur)
+			region.Min = Coord{minInt(region.Min.X, cur.X), minInt(region.Min.Y, cur.Y)}
+			region.Max = Coord{maxInt(region.Max.X, cur.X), maxInt(region.Max.Y, cur.Y)}
+			for _, c := range s.GetCoordsAround(cur) {
+				if s.IsFilled(c) || r.ids[s.index(c)] != -1 {
+					continue
+				}
+				r.ids[s.index(c)] = region.ID
+				queue = append(queue, c)
+			}
+		}
+		r.regions = append(r.regions, region)
+	}
+	return r
+}
+
+// All returns all regions, ordered by ID.
+func (r *Regions) All() []Region {
+	return r.regions
+}
+
+// Count returns the number of regions.
+func (r *Regions) Count() int {
+	return len(r.regions)
+}
+
+// Get returns the region with the given ID. Returns false if no such region exists.
+func (r *Regions) Get(id int) (Region, bool) {
+	if id < 0 || id >= len(r.regions) {
+		return Region{}, false
+	}
+	return r.regions[id], true
+}
+
+// RegionOf returns the region that the given coord is part of.
+// Returns false if the coord is filled or does not fit on the surface.
+func (r *Regions) RegionOf(coord Coord) (Region, bool) {
+	coord = r.s.Wrap(coord)
+	if !r.s.Fits(coord) {
+		return Region{}, false
+	}
+	return r.Get(r.ids[r.s.index(coord)])
+}
+
+// Largest returns the region with the most coords. If multiple regions are the largest, the one with the lowest ID
+// is returned. Returns false if there are no regions.
+func (r *Regions) Largest() (Region, bool) {
+	if len(r.regions) == 0 {
+		return Region{}, false
+	}
+	largest := r.regions[0]
+	for _, region := range r.regions[1:] {
+		if region.Size() > largest.Size() {
+			largest = region
+		}
+	}
+	return largest, true
+}
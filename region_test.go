@@ -0,0 +1,117 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_Surface_GetRegions(t *testing.T) {
+	Convey("Surface.GetRegions()", t, func() {
+		// . . x . .
+		// . . x x x
+		// x x x . .
+		// . . x . .
+		s := NewSurface(5, 4)
+		s.fillRows([][]int{
+			{0, 0, 1, 0, 0},
+			{0, 0, 1, 1, 1},
+			{1, 1, 1, 0, 0},
+			{0, 0, 1, 0, 0},
+		})
+
+		Convey("Labels each separate region", func() {
+			regions := s.GetRegions()
+
+			So(regions.Count(), ShouldEqual, 4)
+			all := regions.All()
+			So(all[0].Coords, ShouldResemble, Coords{{0, 0}, {1, 0}})
+			So(all[1].Coords.Equals(Coords{{3, 0}, {4, 0}, {3, 1}, {4, 1}}), ShouldBeTrue)
+			So(all[2].Coords.Equals(Coords{{0, 2}, {1, 2}, {0, 3}, {1, 3}}), ShouldBeTrue)
+			So(all[3].Coords, ShouldResemble, Coords{{3, 3}, {4, 3}})
+			for i, region := range all {
+				So(region.ID, ShouldEqual, i)
+			}
+		})
+
+		Convey("Returns the bounding rectangle of each region", func() {
+			all := s.GetRegions().All()
+
+			So(all[1].Min, ShouldResemble, Coord{3, 0})
+			So(all[1].Max, ShouldResemble, Coord{4, 1})
+			So(all[2].Min, ShouldResemble, Coord{0, 2})
+			So(all[2].Max, ShouldResemble, Coord{1, 3})
+		})
+
+		Convey("RegionOf()", func() {
+			regions := s.GetRegions()
+
+			Convey("Returns the region of an unfilled coord", func() {
+				region, ok := regions.RegionOf(Coord{4, 1})
+
+				So(ok, ShouldBeTrue)
+				So(region.ID, ShouldEqual, 1)
+				So(region.Size(), ShouldEqual, 4)
+			})
+
+			Convey("Returns false for filled coords", func() {
+				_, ok := regions.RegionOf(Coord{2, 0})
+
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Returns false for coords that do not fit", func() {
+				_, ok := regions.RegionOf(Coord{5, 0})
+
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("Largest()", func() {
+			Convey("Returns the first of the largest regions", func() {
+				region, ok := s.GetRegions().Largest()
+
+				So(ok, ShouldBeTrue)
+				So(region.ID, ShouldEqual, 1)
+			})
+
+			Convey("Returns false if there are no regions", func() {
+				full := NewSurface(1, 1)
+				full.Fill(Coord{0, 0})
+
+				_, ok := full.GetRegions().Largest()
+
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("If eight connected", func() {
+			// . x
+			// x .
+			diagonal := NewSurface(2, 2)
+			diagonal.Fill(Coord{1, 1}, Coord{0, 0})
+			So(diagonal.GetRegions().Count(), ShouldEqual, 2)
+
+			diagonal.SetConnectivity(EightConnected)
+
+			Convey("Connects regions diagonally", func() {
+				regions := diagonal.GetRegions()
+
+				So(regions.Count(), ShouldEqual, 1)
+				So(regions.All()[0].Size(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("If wrapped", func() {
+			wrapped := NewWrappedSurface(5, 4)
+			wrapped.Fill(s.GetFilled()...)
+
+			Convey("Connects regions across the edges", func() {
+				regions := wrapped.GetRegions()
+
+				So(regions.Count(), ShouldEqual, 1)
+				So(regions.All()[0].Size(), ShouldEqual, 12)
+			})
+		})
+	})
+}
@@ -23,23 +23,39 @@ func GetRender(s *Surface) string {
 		rows = append(rows, vals)
 	}
 
+	verticalAxis, horizontalAxis := getAxes(s)
 	rowVals := make([]string, 0, len(rows))
 	for i, row := range rows {
-		row = append([]string{fmt.Sprintf("%02d |", s.height-i-1)}, row...)
+		row = append([]string{fmt.Sprintf("%02d %s", s.height-i-1, verticalAxis)}, row...)
 		rowVals = append(rowVals, strings.Join(row, " "))
 	}
 
-	rowVals = append(rowVals, "    "+strings.Repeat("-", s.width*2))
-
-	xLegendVals := []string{"    "}
-	for x := 0; x < s.width; x++ {
-		xLegendVals = append(xLegendVals, strconv.Itoa(x))
-	}
-	rowVals = append(rowVals, strings.Join(xLegendVals, " "))
+	rowVals = append(rowVals, "    "+strings.Repeat(horizontalAxis, s.width*2))
+	rowVals = append(rowVals, getXLegend(s.width)...)
 
 	return "\n" + strings.Join(rowVals, "\n")
 }
 
+// getXLegend returns the lines of the legend below the horizontal axis of GetRender. Every x is written in the single
+// column of its cells, so for boards wider than 10 columns, the tens are written on a line above the units.
+func getXLegend(width int) []string {
+	numDigits := len(strconv.Itoa(maxInt(width-1, 0)))
+	lines := make([]string, 0, numDigits)
+	for digit := numDigits - 1; digit >= 0; digit-- {
+		vals := []string{"    "}
+		for x := 0; x < width; x++ {
+			xStr := strconv.Itoa(x)
+			if len(xStr) <= digit {
+				vals = append(vals, " ")
+				continue
+			}
+			vals = append(vals, string(xStr[len(xStr)-digit-1]))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(vals, " "), " "))
+	}
+	return lines
+}
+
 // GetRenderWithValues is a utility function to render a given surface to stdout,
 // not displaying only what is filled, but the filled value.
 func GetRenderWithValues(s *Surface) string {
@@ -59,13 +75,14 @@ func GetRenderWithValues(s *Surface) string {
 		rows = append(rows, vals)
 	}
 
+	verticalAxis, horizontalAxis := getAxes(s)
 	rowVals := make([]string, 0, len(rows))
 	for i, row := range rows {
-		row = append([]string{fmt.Sprintf("%02d |", s.height-i-1)}, row...)
+		row = append([]string{fmt.Sprintf("%02d %s", s.height-i-1, verticalAxis)}, row...)
 		rowVals = append(rowVals, strings.Join(row, " "))
 	}
 
-	rowVals = append(rowVals, "    "+strings.Repeat("-", s.width*3))
+	rowVals = append(rowVals, "    "+strings.Repeat(horizontalAxis, s.width*3))
 
 	xLegendVals := []string{"    "}
 	for x := 0; x < s.width; x++ {
@@ -75,3 +92,12 @@ func GetRenderWithValues(s *Surface) string {
 
 	return "\n" + strings.Join(rowVals, "\n")
 }
+
+// getAxes returns the characters with which the vertical and horizontal axes of the given surface are drawn.
+// The axes of wrapped surfaces are drawn with tildes, to show that the edges connect to the opposite edges.
+func getAxes(s *Surface) (string, string) {
+	if s.wrapped {
+		return "~", "~"
+	}
+	return "|", "-"
+}
@@ -0,0 +1,277 @@
+package plane
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ansiCellWidth is the number of columns that each coord takes up in ANSI renders, so that distances up to 99 fit.
+const ansiCellWidth = 2
+
+// ANSIColor is one of the 256 colours of ANSI terminals. The zero value is no colour, which leaves the colour of the
+// layers below.
+type ANSIColor struct {
+	index uint8
+	isSet bool
+}
+
+// ANSI256 returns the ANSI colour with the given index in the 256 colour palette.
+func ANSI256(index uint8) ANSIColor {
+	return ANSIColor{index: index, isSet: true}
+}
+
+// The 16 standard ANSI colours.
+var (
+	ANSIBlack         = ANSI256(0)
+	ANSIRed           = ANSI256(1)
+	ANSIGreen         = ANSI256(2)
+	ANSIYellow        = ANSI256(3)
+	ANSIBlue          = ANSI256(4)
+	ANSIMagenta       = ANSI256(5)
+	ANSICyan          = ANSI256(6)
+	ANSIWhite         = ANSI256(7)
+	ANSIBrightBlack   = ANSI256(8)
+	ANSIBrightRed     = ANSI256(9)
+	ANSIBrightGreen   = ANSI256(10)
+	ANSIBrightYellow  = ANSI256(11)
+	ANSIBrightBlue    = ANSI256(12)
+	ANSIBrightMagenta = ANSI256(13)
+	ANSIBrightCyan    = ANSI256(14)
+	ANSIBrightWhite   = ANSI256(15)
+)
+
+// CellStyle describes how a coord is drawn in ANSI renders. Empty fields leave what the layers below drew.
+type CellStyle struct {
+	// Glyph is drawn right-aligned in two columns. Longer glyphs are cut off.
+	Glyph      string
+	Foreground ANSIColor
+	Background ANSIColor
+}
+
+// over returns the style that results from drawing the current style on top of the given other style.
+func (cs CellStyle) over(other CellStyle) CellStyle {
+	if cs.Glyph != "" {
+		other.Glyph = cs.Glyph
+	}
+	if cs.Foreground.isSet {
+		other.Foreground = cs.Foreground
+	}
+	if cs.Background.isSet {
+		other.Background = cs.Background
+	}
+	return other
+}
+
+// render returns the style's glyph, padded to the width of a cell, and wrapped in ANSI escape codes unless noColor is
+// true.
+func (cs CellStyle) render(noColor bool) string {
+	glyph := cs.Glyph
+	if n := utf8.RuneCountInString(glyph); n < ansiCellWidth {
+		glyph = strings.Repeat(" ", ansiCellWidth-n) + glyph
+	} else if n > ansiCellWidth {
+		glyph = string([]rune(glyph)[:ansiCellWidth])
+	}
+	if noColor || (!cs.Foreground.isSet && !cs.Background.isSet) {
+		return glyph
+	}
+
+	var b strings.Builder
+	if cs.Foreground.isSet {
+		fmt.Fprintf(&b, "\x1b[38;5;%dm", cs.Foreground.index)
+	}
+	if cs.Background.isSet {
+		fmt.Fprintf(&b, "\x1b[48;5;%dm", cs.Background.index)
+	}
+	b.WriteString(glyph)
+	b.WriteString("\x1b[0m")
+	return b.String()
+}
+
+// Layer is drawn on top of the layers below it in ANSI renders.
+type Layer interface {
+	// Draw returns how to draw the given coord, or false if the layer does not draw on it.
+	Draw(s *Surface, coord Coord) (CellStyle, bool)
+}
+
+// layerPreparer is implemented by layers that are drawn quicker if they are prepared for a surface before drawing
+// all coords of it, for example by putting their coords in a set.
+type layerPreparer interface {
+	prepare(s *Surface) Layer
+}
+
+// FilledLayer draws the filled coords. The glyph defaults to a full block.
+type FilledLayer struct {
+	Style CellStyle
+}
+
+// Draw satisfies Layer.
+func (l FilledLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	if !s.IsFilled(coord) {
+		return CellStyle{}, false
+	}
+	return l.Style.over(CellStyle{Glyph: "██"}), true
+}
+
+// CoordsLayer draws the given coords, e.g. to highlight the heads of opponents or a flooded region.
+type CoordsLayer struct {
+	Coords Coords
+	Style  CellStyle
+}
+
+// Draw satisfies Layer.
+func (l CoordsLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	return l.prepare(s).Draw(s, coord)
+}
+
+// prepare satisfies layerPreparer.
+func (l CoordsLayer) prepare(s *Surface) Layer {
+	set := make(CoordSet, len(l.Coords))
+	for _, coord := range l.Coords {
+		set.Add(s.Wrap(coord))
+	}
+	return preparedCoordsLayer{set: set, style: l.Style}
+}
+
+// preparedCoordsLayer is a CoordsLayer of which the coords can be looked up in constant time.
+type preparedCoordsLayer struct {
+	set   CoordSet
+	style CellStyle
+}
+
+// Draw satisfies Layer.
+func (l preparedCoordsLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	return l.style, l.set.Has(coord)
+}
+
+// PathLayer draws a path, with arrows that point to the next coord of the path. The glyph defaults to the arrows, and
+// to a dot for the last coord.
+type PathLayer struct {
+	Path  Coords
+	Style CellStyle
+}
+
+// pathArrows are the arrows that point in each direction.
+var pathArrows = map[Direction]string{
+	Top:      "↑",
+	Right:    "→",
+	Bot:      "↓",
+	Left:     "←",
+	TopRight: "↗",
+	BotRight: "↘",
+	BotLeft:  "↙",
+	TopLeft:  "↖",
+}
+
+// Draw satisfies Layer. If the path passes the coord more than once, the last pass is drawn.
+func (l PathLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	return l.prepare(s).Draw(s, coord)
+}
+
+// prepare satisfies layerPreparer.
+func (l PathLayer) prepare(s *Surface) Layer {
+	styles := make(map[Coord]CellStyle, len(l.Path))
+	for i, coord := range l.Path {
+		glyph := "•"
+		if i < len(l.Path)-1 {
+			if d, ok := s.getDirectionTo(coord, l.Path[i+1]); ok {
+				glyph = pathArrows[d]
+			}
+		}
+		styles[s.Wrap(coord)] = l.Style.over(CellStyle{Glyph: glyph})
+	}
+	return preparedPathLayer{styles: styles}
+}
+
+// preparedPathLayer is a PathLayer of which the style of each coord is known.
+type preparedPathLayer struct {
+	styles map[Coord]CellStyle
+}
+
+// Draw satisfies Layer.
+func (l preparedPathLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	style, ok := l.styles[coord]
+	return style, ok
+}
+
+// DistanceLayer draws the distances of the unfilled coords that have a distance, e.g. after a flood. The glyph
+// defaults to the distance, or to ++ for distances above 99.
+type DistanceLayer struct {
+	// Distances, if given, are drawn instead of the distances on the surface.
+	Distances *DistanceField
+	Style     CellStyle
+}
+
+// Draw satisfies Layer.
+func (l DistanceLayer) Draw(s *Surface, coord Coord) (CellStyle, bool) {
+	distance, ok := l.getDistance(s, coord)
+	if !ok {
+		return CellStyle{}, false
+	}
+	glyph := strconv.Itoa(distance)
+	if distance > 99 {
+		glyph = "++"
+	}
+	return l.Style.over(CellStyle{Glyph: glyph}), true
+}
+
+// getDistance returns the distance to draw at the given coord. Returns false if there is none.
+func (l DistanceLayer) getDistance(s *Surface, coord Coord) (int, bool) {
+	if !s.Fits(coord) || s.IsFilled(coord) {
+		return 0, false
+	}
+	if l.Distances != nil {
+		return l.Distances.reachedAt(coord)
+	}
+	distance := s.cells[s.index(coord)].distance
+	return distance, distance > 0
+}
+
+// ANSIRenderOptions describe what to draw in ANSI renders.
+type ANSIRenderOptions struct {
+	// Layers are drawn on top of each other, the first layer at the bottom. Defaults to a FilledLayer.
+	Layers []Layer
+	// NoColor leaves out the ANSI escape codes, e.g. when not writing to a terminal. Glyphs are still drawn.
+	NoColor bool
+}
+
+// GetANSIRender renders the given surface like GetRender, but composites the given layers on top of each other, in
+// colour.
+func GetANSIRender(s *Surface, opts ANSIRenderOptions) string {
+	layers := opts.Layers
+	if layers == nil {
+		layers = []Layer{FilledLayer{}}
+	}
+	prepared := make([]Layer, len(layers))
+	for i, layer := range layers {
+		if preparer, ok := layer.(layerPreparer); ok {
+			layer = preparer.prepare(s)
+		}
+		prepared[i] = layer
+	}
+
+	verticalAxis, horizontalAxis := getAxes(s)
+	rowVals := make([]string, 0, s.height+2)
+	for y := s.height - 1; y >= 0; y-- {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%02d %s", y, verticalAxis)
+		for x := 0; x < s.width; x++ {
+			coord := Coord{x, y}
+			style := CellStyle{Glyph: "·"}
+			for _, layer := range prepared {
+				if layerStyle, ok := layer.Draw(s, coord); ok {
+					style = layerStyle.over(style)
+				}
+			}
+			b.WriteString(style.render(opts.NoColor))
+		}
+		rowVals = append(rowVals, b.String())
+	}
+
+	rowVals = append(rowVals, "    "+strings.Repeat(horizontalAxis, s.width*ansiCellWidth+1))
+
+	rowVals = append(rowVals, getXLegend(s.width)...)
+
+	return "\n" + strings.Join(rowVals, "\n")
+}
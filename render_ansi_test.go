@@ -0,0 +1,130 @@
+package plane
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_GetANSIRender(t *testing.T) {
+	Convey("GetANSIRender()", t, func() {
+		// . . .
+		// . . x
+		s := NewSurface(3, 2)
+		s.Fill(Coord{2, 0})
+
+		Convey("Draws the filled coords by default", func() {
+			So(GetANSIRender(s, ANSIRenderOptions{}), ShouldEqual, `
+01 | · · ·
+00 | · ·██
+    -------
+     0 1 2`)
+		})
+
+		Convey("Aligns the x legend on boards wider than 10 columns", func() {
+			wide := NewSurface(12, 1)
+			wide.Fill(Coord{11, 0})
+
+			So(GetANSIRender(wide, ANSIRenderOptions{}), ShouldEqual, `
+00 | · · · · · · · · · · ·██
+    -------------------------
+                         1 1
+     0 1 2 3 4 5 6 7 8 9 0 1`)
+		})
+
+		Convey("Draws the layers on top of each other", func() {
+			render := GetANSIRender(s, ANSIRenderOptions{
+				Layers: []Layer{
+					FilledLayer{},
+					PathLayer{Path: Coords{{0, 0}, {0, 1}, {1, 1}}},
+					CoordsLayer{Coords: Coords{{1, 1}, {2, 0}}, Style: CellStyle{Glyph: "H"}},
+				},
+			})
+
+			So(render, ShouldEqual, `
+01 | → H ·
+00 | ↑ · H
+    -------
+     0 1 2`)
+		})
+
+		Convey("Draws paths across the edges of wrapped surfaces", func() {
+			wrapped := NewWrappedSurface(3, 1)
+
+			render := GetANSIRender(wrapped, ANSIRenderOptions{
+				Layers: []Layer{PathLayer{Path: Coords{{1, 0}, {0, 0}, {-1, 0}}}},
+			})
+
+			So(render, ShouldEqual, `
+00 ~ ← ← •
+    ~~~~~~~
+     0 1 2`)
+		})
+
+		Convey("Draws distances", func() {
+			NewFloodFiller(s).CountSteps(Coord{0, 0}, Coord{2, 1})
+
+			render := GetANSIRender(s, ANSIRenderOptions{Layers: []Layer{FilledLayer{}, DistanceLayer{}}})
+
+			So(render, ShouldEqual, `
+01 | 1 2 3
+00 | · 1██
+    -------
+     0 1 2`)
+		})
+
+		Convey("Draws the distances of the given distance field", func() {
+			field := NewFloodFiller(s).DistanceField(Coord{0, 0})
+
+			render := GetANSIRender(s, ANSIRenderOptions{Layers: []Layer{FilledLayer{}, DistanceLayer{Distances: field}}})
+
+			So(render, ShouldEqual, `
+01 | 1 2 3
+00 | · 1██
+    -------
+     0 1 2`)
+		})
+
+		Convey("Colours the cells, keeping the colours and glyphs of the layers below that are not overridden", func() {
+			render := GetANSIRender(s, ANSIRenderOptions{
+				Layers: []Layer{
+					FilledLayer{Style: CellStyle{Foreground: ANSIRed}},
+					CoordsLayer{Coords: Coords{{2, 0}}, Style: CellStyle{Background: ANSI256(236)}},
+				},
+			})
+
+			So(render, ShouldContainSubstring, "00 | · ·\x1b[38;5;1m\x1b[48;5;236m██\x1b[0m\n")
+		})
+
+		Convey("Leaves out the colours if asked to", func() {
+			render := GetANSIRender(s, ANSIRenderOptions{
+				Layers:  []Layer{FilledLayer{Style: CellStyle{Foreground: ANSIRed}}},
+				NoColor: true,
+			})
+
+			So(render, ShouldContainSubstring, "00 | · ·██\n")
+		})
+	})
+}
+
+func Test_CellStyle_render(t *testing.T) {
+	Convey("CellStyle.render()", t, func() {
+		testCases := []struct {
+			description string
+			glyph       string
+			expected    string
+		}{
+			{"pads short glyphs", "x", " x"},
+			{"pads unicode glyphs by their number of runes", "█", " █"},
+			{"keeps glyphs of two runes", "██", "██"},
+			{"cuts off long glyphs", "123", "12"},
+		}
+
+		for i, tc := range testCases {
+			Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
+				So(CellStyle{Glyph: tc.glyph}.render(false), ShouldEqual, tc.expected)
+			})
+		}
+	})
+}
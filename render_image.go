@@ -0,0 +1,316 @@
+package plane
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// defaultCellSize is the width and height in pixels of each coord in images, if no other size is given.
+const defaultCellSize = 16
+
+// Palette holds the colours with which surfaces are drawn as images.
+type Palette struct {
+	Background color.Color
+	Grid       color.Color
+	Filled     color.Color
+	Path       color.Color
+	Highlight  color.Color
+	// HeatmapNear and HeatmapFar are the colours of the nearest and farthest coords in distance heatmaps. The colours
+	// of the coords in between are mixed from them.
+	HeatmapNear color.Color
+	HeatmapFar  color.Color
+}
+
+// DefaultPalette is the palette that is used for colours that are not given.
+var DefaultPalette = Palette{
+	Background:  color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Grid:        color.RGBA{0xe0, 0xe0, 0xe0, 0xff},
+	Filled:      color.RGBA{0x33, 0x33, 0x33, 0xff},
+	Path:        color.RGBA{0x1e, 0x88, 0xe5, 0xff},
+	Highlight:   color.RGBA{0xe5, 0x39, 0x35, 0xff},
+	HeatmapNear: color.RGBA{0xff, 0xf1, 0x76, 0xff},
+	HeatmapFar:  color.RGBA{0xef, 0x6c, 0x00, 0xff},
+}
+
+// ImageOptions describe what to draw when drawing a surface as an image.
+type ImageOptions struct {
+	// CellSize is the width and height in pixels of each coord. Defaults to 16.
+	CellSize int
+	// Palette holds the colours to draw with. Colours that are not given are taken from DefaultPalette.
+	Palette Palette
+	// Heatmap colours the unfilled coords that have a distance, e.g. after a flood, from near to far.
+	Heatmap bool
+	// Distances, if given, are drawn as a heatmap instead of the distances on the surface, even if Heatmap is false.
+	Distances *DistanceField
+	// Paths are drawn as lines through the centers of their coords.
+	Paths []Coords
+	// Highlighted coords are outlined.
+	Highlighted Coords
+}
+
+// withDefaults returns the options, with defaults for what was not given.
+func (o ImageOptions) withDefaults() ImageOptions {
+	if o.CellSize <= 0 {
+		o.CellSize = defaultCellSize
+	}
+	p := &o.Palette
+	for _, c := range []struct {
+		color        *color.Color
+		defaultColor color.Color
+	}{
+		{&p.Background, DefaultPalette.Background},
+		{&p.Grid, DefaultPalette.Grid},
+		{&p.Filled, DefaultPalette.Filled},
+		{&p.Path, DefaultPalette.Path},
+		{&p.Highlight, DefaultPalette.Highlight},
+		{&p.HeatmapNear, DefaultPalette.HeatmapNear},
+		{&p.HeatmapFar, DefaultPalette.HeatmapFar},
+	} {
+		if *c.color == nil {
+			*c.color = c.defaultColor
+		}
+	}
+	return o
+}
+
+// GetImage draws the given surface as an image. Like in GetRender, 0,0 is at the bottom left.
+func GetImage(s *Surface, opts ImageOptions) image.Image {
+	opts = opts.withDefaults()
+	size := opts.CellSize
+	img := image.NewRGBA(image.Rect(0, 0, s.width*size, s.height*size))
+	fillRect(img, img.Bounds(), opts.Palette.Background)
+
+	// Cells.
+	distances, maxDistance := opts.getHeatmapDistances(s)
+	for i, cell := range s.cells {
+		coord := s.coordAt(i)
+		if c := opts.getCellColor(cell, distances[i], maxDistance); c != nil {
+			fillRect(img, getCellRect(s, coord, size), c)
+		}
+	}
+
+	// Grid, on the left and top edges of each cell.
+	if size > 2 {
+		for x := 0; x < s.width; x++ {
+			fillRect(img, image.Rect(x*size, 0, x*size+1, s.height*size), opts.Palette.Grid)
+		}
+		for y := 0; y < s.height; y++ {
+			fillRect(img, image.Rect(0, y*size, s.width*size, y*size+1), opts.Palette.Grid)
+		}
+	}
+
+	// Paths.
+	thickness := maxInt(size/4, 1)
+	for _, path := range opts.Paths {
+		for i, coord := range path {
+			center := getCellCenter(s, coord, size)
+			fillRect(img, getSquare(center, maxInt(size/3, 1)), opts.Palette.Path)
+			if i > 0 && path[i-1].isNextTo(coord) {
+				drawLine(img, getCellCenter(s, path[i-1], size), center, thickness, opts.Palette.Path)
+			}
+		}
+	}
+
+	// Highlighted coords.
+	border := maxInt(size/8, 1)
+	for _, coord := range opts.Highlighted {
+		r := getCellRect(s, coord, size)
+		fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+border), opts.Palette.Highlight)
+		fillRect(img, image.Rect(r.Min.X, r.Max.Y-border, r.Max.X, r.Max.Y), opts.Palette.Highlight)
+		fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+border, r.Max.Y), opts.Palette.Highlight)
+		fillRect(img, image.Rect(r.Max.X-border, r.Min.Y, r.Max.X, r.Max.Y), opts.Palette.Highlight)
+	}
+
+	return img
+}
+
+// WritePNG draws the given surface as an image, and writes it to w as a PNG.
+func WritePNG(w io.Writer, s *Surface, opts ImageOptions) error {
+	if err := png.Encode(w, GetImage(s, opts)); err != nil {
+		return fmt.Errorf("could not encode png: %w", err)
+	}
+	return nil
+}
+
+// GetSVG draws the given surface as an SVG image. Like in GetRender, 0,0 is at the bottom left.
+func GetSVG(s *Surface, opts ImageOptions) string {
+	opts = opts.withDefaults()
+	size := opts.CellSize
+	width, height := s.width*size, s.height*size
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" %s/>`+"\n", width, height, getSVGPaint("fill", opts.Palette.Background))
+
+	// Cells.
+	distances, maxDistance := opts.getHeatmapDistances(s)
+	for i, cell := range s.cells {
+		c := opts.getCellColor(cell, distances[i], maxDistance)
+		if c == nil {
+			continue
+		}
+		r := getCellRect(s, s.coordAt(i), size)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n", r.Min.X, r.Min.Y, size, size, getSVGPaint("fill", c))
+	}
+
+	// Grid.
+	var grid strings.Builder
+	for x := 0; x <= s.width; x++ {
+		fmt.Fprintf(&grid, "M%d 0V%d", x*size, height)
+	}
+	for y := 0; y <= s.height; y++ {
+		fmt.Fprintf(&grid, "M0 %dH%d", y*size, width)
+	}
+	fmt.Fprintf(&b, `<path d="%s" %s stroke-width="1"/>`+"\n", grid.String(), getSVGPaint("stroke", opts.Palette.Grid))
+
+	// Paths, split where they cross the edges of wrapped surfaces.
+	for _, path := range opts.Paths {
+		var points []string
+		flush := func() {
+			if len(points) > 1 {
+				fmt.Fprintf(
+					&b,
+					`<polyline points="%s" fill="none" %s stroke-width="%d" stroke-linecap="round" stroke-linejoin="round"/>`+"\n",
+					strings.Join(points, " "),
+					getSVGPaint("stroke", opts.Palette.Path),
+					maxInt(size/4, 1),
+				)
+			}
+			points = points[:0]
+		}
+		for i, coord := range path {
+			center := getCellCenter(s, coord, size)
+			if i > 0 && !path[i-1].isNextTo(coord) {
+				flush()
+			}
+			points = append(points, fmt.Sprintf("%d,%d", center.X, center.Y))
+			fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" %s/>`+"\n", center.X, center.Y, maxInt(size/6, 1), getSVGPaint("fill", opts.Palette.Path))
+		}
+		flush()
+	}
+
+	// Highlighted coords.
+	border := maxInt(size/8, 1)
+	for _, coord := range opts.Highlighted {
+		r := getCellRect(s, coord, size)
+		fmt.Fprintf(
+			&b,
+			`<rect x="%g" y="%g" width="%d" height="%d" fill="none" %s stroke-width="%d"/>`+"\n",
+			float64(r.Min.X)+float64(border)/2,
+			float64(r.Min.Y)+float64(border)/2,
+			size-border,
+			size-border,
+			getSVGPaint("stroke", opts.Palette.Highlight),
+			border,
+		)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// getCellColor returns the colour to draw the given cell with, given its distance in the heatmap, or nil if only the
+// background should be drawn.
+func (o ImageOptions) getCellColor(cell coordVal, distance, maxDistance int) color.Color {
+	if cell.isFilled {
+		return o.Palette.Filled
+	}
+	if distance > 0 {
+		return mixColors(o.Palette.HeatmapNear, o.Palette.HeatmapFar, distance-1, maxDistance-1)
+	}
+	return nil
+}
+
+// getHeatmapDistances returns the distances to draw in the heatmap, indexed like the cells of the given surface, and
+// the largest of them. Filled coords and coords that are not drawn in the heatmap have distance 0.
+func (o ImageOptions) getHeatmapDistances(s *Surface) ([]int, int) {
+	distances := make([]int, len(s.cells))
+	var maxDistance int
+	switch {
+	case o.Distances != nil:
+		o.Distances.Each(func(coord Coord, distance int) bool {
+			if s.Fits(coord) && !s.IsFilled(coord) {
+				distances[s.index(coord)] = distance
+				maxDistance = maxInt(maxDistance, distance)
+			}
+			return true
+		})
+	case o.Heatmap:
+		for i, cell := range s.cells {
+			if !cell.isFilled {
+				distances[i] = cell.distance
+				maxDistance = maxInt(maxDistance, cell.distance)
+			}
+		}
+	}
+	return distances, maxDistance
+}
+
+// isNextTo returns true if the given other coord is at most a single step away, diagonally or not.
+func (c Coord) isNextTo(other Coord) bool {
+	return abs(c.X-other.X) <= 1 && abs(c.Y-other.Y) <= 1
+}
+
+// getCellRect returns the rectangle in which the given coord is drawn. The y axis is flipped, as images start at the
+// top left.
+func getCellRect(s *Surface, coord Coord, size int) image.Rectangle {
+	x, y := coord.X*size, (s.height-coord.Y-1)*size
+	return image.Rect(x, y, x+size, y+size)
+}
+
+// getCellCenter returns the pixel at the center of the given coord.
+func getCellCenter(s *Surface, coord Coord, size int) image.Point {
+	r := getCellRect(s, coord, size)
+	return image.Pt(r.Min.X+size/2, r.Min.Y+size/2)
+}
+
+// getSquare returns a square of the given size around the given center.
+func getSquare(center image.Point, size int) image.Rectangle {
+	topLeft := center.Sub(image.Pt(size/2, size/2))
+	return image.Rectangle{Min: topLeft, Max: topLeft.Add(image.Pt(size, size))}
+}
+
+// drawLine draws a line of the given thickness between the given points.
+func drawLine(img draw.Image, from, to image.Point, thickness int, c color.Color) {
+	steps := maxInt(abs(to.X-from.X), abs(to.Y-from.Y))
+	for i := 0; i <= steps; i++ {
+		p := from
+		if steps > 0 {
+			p = from.Add(to.Sub(from).Mul(i).Div(steps))
+		}
+		fillRect(img, getSquare(p, thickness), c)
+	}
+}
+
+func fillRect(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{C: c}, image.Point{}, draw.Over)
+}
+
+// mixColors returns the colour that is step/numSteps of the way from one colour to the other.
+func mixColors(from, to color.Color, step, numSteps int) color.Color {
+	if numSteps <= 0 {
+		return from
+	}
+	r1, g1, b1, a1 := from.RGBA()
+	r2, g2, b2, a2 := to.RGBA()
+	mix := func(v1, v2 uint32) uint16 {
+		return uint16((int(v1)*(numSteps-step) + int(v2)*step) / numSteps)
+	}
+	return color.RGBA64{mix(r1, r2), mix(g1, g2), mix(b1, b2), mix(a1, a2)}
+}
+
+// getSVGPaint returns the SVG attributes that paint with the given colour.
+func getSVGPaint(attr string, c color.Color) string {
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	paint := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, rgba.R, rgba.G, rgba.B)
+	if rgba.A != 0xff {
+		paint += fmt.Sprintf(` %s-opacity="%.3g"`, attr, float64(rgba.A)/0xff)
+	}
+	return paint
+}
@@ -0,0 +1,189 @@
+package plane
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// getPixel returns the colour of the given pixel as RGBA.
+func getPixel(img image.Image, x, y int) color.RGBA {
+	return color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+}
+
+func Test_GetImage(t *testing.T) {
+	Convey("GetImage()", t, func() {
+		// . . .
+		// . . x
+		s := NewSurface(3, 2)
+		s.Fill(Coord{2, 0})
+
+		Convey("Sizes the image by the cell size", func() {
+			So(GetImage(s, ImageOptions{}).Bounds(), ShouldResemble, image.Rect(0, 0, 48, 32))
+			So(GetImage(s, ImageOptions{CellSize: 4}).Bounds(), ShouldResemble, image.Rect(0, 0, 12, 8))
+		})
+
+		Convey("Draws filled coords, with 0,0 at the bottom left", func() {
+			img := GetImage(s, ImageOptions{CellSize: 10})
+
+			So(getPixel(img, 25, 15), ShouldResemble, DefaultPalette.Filled)
+			So(getPixel(img, 25, 5), ShouldResemble, DefaultPalette.Background)
+			So(getPixel(img, 5, 15), ShouldResemble, DefaultPalette.Background)
+		})
+
+		Convey("Draws a grid", func() {
+			img := GetImage(s, ImageOptions{CellSize: 10})
+
+			So(getPixel(img, 10, 5), ShouldResemble, DefaultPalette.Grid)
+			So(getPixel(img, 5, 10), ShouldResemble, DefaultPalette.Grid)
+		})
+
+		Convey("Uses the given palette, and the default palette for the colours that are not given", func() {
+			red := color.RGBA{0xff, 0, 0, 0xff}
+
+			img := GetImage(s, ImageOptions{CellSize: 10, Palette: Palette{Filled: red}})
+
+			So(getPixel(img, 25, 15), ShouldResemble, red)
+			So(getPixel(img, 5, 5), ShouldResemble, DefaultPalette.Background)
+		})
+
+		Convey("Draws paths through the centers of their coords", func() {
+			img := GetImage(s, ImageOptions{CellSize: 10, Paths: []Coords{{{0, 0}, {0, 1}, {1, 1}}}})
+
+			So(getPixel(img, 5, 15), ShouldResemble, DefaultPalette.Path)
+			So(getPixel(img, 5, 10), ShouldResemble, DefaultPalette.Path)
+			So(getPixel(img, 10, 5), ShouldResemble, DefaultPalette.Path)
+			So(getPixel(img, 15, 5), ShouldResemble, DefaultPalette.Path)
+			So(getPixel(img, 15, 15), ShouldResemble, DefaultPalette.Background)
+		})
+
+		Convey("Outlines highlighted coords", func() {
+			img := GetImage(s, ImageOptions{CellSize: 16, Highlighted: Coords{{1, 1}}})
+
+			So(getPixel(img, 16, 8), ShouldResemble, DefaultPalette.Highlight)
+			So(getPixel(img, 31, 8), ShouldResemble, DefaultPalette.Highlight)
+			So(getPixel(img, 24, 0), ShouldResemble, DefaultPalette.Highlight)
+			So(getPixel(img, 24, 8), ShouldResemble, DefaultPalette.Background)
+		})
+
+		Convey("Draws a heatmap of the distances", func() {
+			NewFloodFiller(s).CountSteps(Coord{0, 0}, Coord{2, 1})
+			near := color.RGBA{0, 0, 0, 0xff}
+			far := color.RGBA{0, 0, 0xff, 0xff}
+
+			img := GetImage(s, ImageOptions{CellSize: 10, Heatmap: true, Palette: Palette{HeatmapNear: near, HeatmapFar: far}})
+
+			So(getPixel(img, 5, 15), ShouldResemble, DefaultPalette.Background)
+			So(getPixel(img, 15, 15), ShouldResemble, near)
+			So(getPixel(img, 15, 5), ShouldResemble, color.RGBA{0, 0, 0x7f, 0xff})
+			So(getPixel(img, 25, 5), ShouldResemble, far)
+			So(getPixel(img, 25, 15), ShouldResemble, DefaultPalette.Filled)
+		})
+
+		Convey("Draws a heatmap of the given distance field", func() {
+			field := NewFloodFiller(s).DistanceField(Coord{0, 0})
+			near := color.RGBA{0, 0, 0, 0xff}
+			far := color.RGBA{0, 0, 0xff, 0xff}
+
+			img := GetImage(s, ImageOptions{CellSize: 10, Distances: field, Palette: Palette{HeatmapNear: near, HeatmapFar: far}})
+
+			So(getPixel(img, 5, 15), ShouldResemble, DefaultPalette.Background)
+			So(getPixel(img, 15, 15), ShouldResemble, near)
+			So(getPixel(img, 5, 5), ShouldResemble, near)
+			So(getPixel(img, 15, 5), ShouldResemble, color.RGBA{0, 0, 0x7f, 0xff})
+			So(getPixel(img, 25, 5), ShouldResemble, far)
+			So(getPixel(img, 25, 15), ShouldResemble, DefaultPalette.Filled)
+		})
+
+		Convey("Draws no heatmap unless asked to", func() {
+			NewFloodFiller(s).CountSteps(Coord{0, 0}, Coord{2, 1})
+
+			img := GetImage(s, ImageOptions{CellSize: 10})
+
+			So(getPixel(img, 15, 15), ShouldResemble, DefaultPalette.Background)
+		})
+	})
+}
+
+func Test_WritePNG(t *testing.T) {
+	Convey("WritePNG()", t, func() {
+		Convey("Writes the image as a PNG", func() {
+			s := NewSurface(3, 2)
+			s.Fill(Coord{2, 0})
+			var buf bytes.Buffer
+
+			So(WritePNG(&buf, s, ImageOptions{CellSize: 10}), ShouldBeNil)
+
+			img, err := png.Decode(&buf)
+			So(err, ShouldBeNil)
+			So(img.Bounds(), ShouldResemble, image.Rect(0, 0, 30, 20))
+			So(getPixel(img, 25, 15), ShouldResemble, DefaultPalette.Filled)
+		})
+	})
+}
+
+func Test_GetSVG(t *testing.T) {
+	Convey("GetSVG()", t, func() {
+		s := NewSurface(3, 2)
+		s.Fill(Coord{2, 0})
+
+		Convey("Sizes the image by the cell size", func() {
+			svg := GetSVG(s, ImageOptions{CellSize: 10})
+
+			So(svg, ShouldStartWith, `<svg xmlns="http://www.w3.org/2000/svg" width="30" height="20" viewBox="0 0 30 20">`)
+			So(svg, ShouldEndWith, "</svg>\n")
+		})
+
+		Convey("Draws filled coords, with 0,0 at the bottom left", func() {
+			svg := GetSVG(s, ImageOptions{CellSize: 10})
+
+			So(svg, ShouldContainSubstring, `<rect x="20" y="10" width="10" height="10" fill="#333333"/>`)
+			So(strings.Count(svg, `fill="#333333"`), ShouldEqual, 1)
+		})
+
+		Convey("Draws paths, split where they cross the edges", func() {
+			wrapped := NewWrappedSurface(3, 2)
+
+			svg := GetSVG(wrapped, ImageOptions{CellSize: 10, Paths: []Coords{{{1, 0}, {0, 0}, {2, 0}, {1, 0}}}})
+
+			So(svg, ShouldContainSubstring, `<polyline points="15,15 5,15" fill="none" stroke="#1e88e5"`)
+			So(svg, ShouldContainSubstring, `<polyline points="25,15 15,15" fill="none" stroke="#1e88e5"`)
+			So(strings.Count(svg, "<circle"), ShouldEqual, 4)
+		})
+
+		Convey("Outlines highlighted coords", func() {
+			svg := GetSVG(s, ImageOptions{CellSize: 16, Highlighted: Coords{{1, 1}}})
+
+			So(svg, ShouldContainSubstring, `<rect x="17" y="1" width="14" height="14" fill="none" stroke="#e53935" stroke-width="2"/>`)
+		})
+
+		Convey("Draws a heatmap of the distances", func() {
+			NewFloodFiller(s).CountSteps(Coord{0, 0}, Coord{2, 1})
+
+			svg := GetSVG(s, ImageOptions{CellSize: 10, Heatmap: true})
+
+			So(svg, ShouldContainSubstring, `<rect x="0" y="0" width="10" height="10" fill="#fff176"/>`)
+			So(svg, ShouldContainSubstring, `<rect x="20" y="0" width="10" height="10" fill="#ef6c00"/>`)
+		})
+
+		Convey("Draws a heatmap of the given distance field", func() {
+			field := NewFloodFiller(s).DistanceField(Coord{0, 0})
+
+			svg := GetSVG(s, ImageOptions{CellSize: 10, Distances: field})
+
+			So(svg, ShouldContainSubstring, `<rect x="10" y="10" width="10" height="10" fill="#fff176"/>`)
+			So(svg, ShouldContainSubstring, `<rect x="20" y="0" width="10" height="10" fill="#ef6c00"/>`)
+		})
+
+		Convey("Writes the opacity of translucent colours", func() {
+			svg := GetSVG(s, ImageOptions{CellSize: 10, Palette: Palette{Filled: color.NRGBA{0xff, 0, 0, 0x80}}})
+
+			So(svg, ShouldContainSubstring, `fill="#ff0000" fill-opacity="0.502"`)
+		})
+	})
+}
@@ -0,0 +1,40 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_GetRender(t *testing.T) {
+	Convey("GetRender()", t, func() {
+		Convey("Renders the filled coords", func() {
+			s := NewSurface(3, 2)
+			s.Fill(Coord{0, 0})
+
+			So(GetRender(s), ShouldEqual, `
+01 | . . .
+00 | x . .
+    ------
+     0 1 2`)
+		})
+
+		Convey("Writes the tens of the x legend above the units on boards wider than 10 columns", func() {
+			s := NewSurface(12, 1)
+			s.Fill(Coord{11, 0})
+
+			So(GetRender(s), ShouldEqual, `
+00 | . . . . . . . . . . . x
+    ------------------------
+                         1 1
+     0 1 2 3 4 5 6 7 8 9 0 1`)
+		})
+
+		Convey("Draws the axes of wrapped surfaces with tildes", func() {
+			So(GetRender(NewWrappedSurface(2, 1)), ShouldEqual, `
+00 ~ . .
+    ~~~~
+     0 1`)
+		})
+	})
+}
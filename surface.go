@@ -1,18 +1,38 @@
 package plane
 
+import "fmt"
+
 // coordVal describes the values that exist at a coordinate. A coordinate can be filled but have no distance, or have a
 // distance but not be filled. This data is saved for different purposes. We want to know if a position is filled when
 // we want to flood flood (don't flood the same coord twice). We want to know the distance when calculating the distance.
-// In that case, sometimes we have to visit positions twice (because a longer path may have reached a coord before a
-// shorter path).
+// In that case, the distance also tells us if a coord was already visited by the flood.
 type coordVal struct {
 	isFilled bool
 	distance int
+	// cost is the cost of moving onto the coordinate. A cost of 0 means the default cost of 1 is used.
+	cost int
+	// freeAt is the step from which a filled coordinate is free. A value of 0 means it is filled forever.
+	freeAt int
+	// kind and owner describe what is at the coordinate. See Cell.
+	kind  CellKind
+	owner int
 }
 
-// surfaceMap defines which coordinates in a surface are filled.
-// The structure is [x][y]coordVal.
-type surfaceMap map[int]map[int]coordVal
+// Connectivity defines which coords are next to each other, and can thus be moved between in a single step.
+type Connectivity int
+
+const (
+	// FourConnected means that each coord is next to the coords above, below, left and right of it.
+	FourConnected Connectivity = 4
+	// EightConnected means that each coord is next to the coords above, below, left and right of it, and to the
+	// diagonal coords.
+	EightConnected Connectivity = 8
+)
+
+// isValid returns true if the connectivity is one of the supported connectivities.
+func (c Connectivity) isValid() bool {
+	return c == FourConnected || c == EightConnected
+}
 
 // Surface represents a surface of a given width and height.
 // For width 5 and height 5, the coordinates would range from 0-4x and 0-4y.
@@ -20,21 +40,98 @@ type surfaceMap map[int]map[int]coordVal
 type Surface struct {
 	width  int
 	height int
-	// surface keeps track of which coordinates are filled.
-	surface surfaceMap
+	// wrapped is true if the edges of the surface connect to the opposite edges.
+	wrapped      bool
+	connectivity Connectivity
+	// cells keeps track of the values of all coordinates, row by row, starting at 0,0.
+	// The value of coord x,y is found at index y*width+x.
+	cells []coordVal
 }
 
 // NewSurface returns a new surface.
 func NewSurface(width int, height int) *Surface {
-	s := make(surfaceMap, width)
-	for i := 0; i < width; i++ {
-		s[i] = make(map[int]coordVal, height)
-	}
 	return &Surface{
-		width:   width,
-		height:  height,
-		surface: s,
+		width:        width,
+		height:       height,
+		connectivity: FourConnected,
+		cells:        make([]coordVal, width*height),
+	}
+}
+
+// NewWrappedSurface returns a new surface of which the edges connect to the opposite edges, so that moving off one edge
+// enters the surface again at the opposite edge. For width 5, the coord to the left of 0,0 is 4,0.
+func NewWrappedSurface(width int, height int) *Surface {
+	s := NewSurface(width, height)
+	s.wrapped = true
+	return s
+}
+
+// IsWrapped returns true if the edges of the surface connect to the opposite edges.
+func (s *Surface) IsWrapped() bool {
+	return s.wrapped
+}
+
+// SetConnectivity sets which coords are next to each other. By default, surfaces are FourConnected.
+// With EightConnected, flood filling and path finding also move diagonally.
+// Returns an error and leaves the surface as is for any other connectivity.
+func (s *Surface) SetConnectivity(connectivity Connectivity) error {
+	if !connectivity.isValid() {
+		return fmt.Errorf("invalid connectivity %d", connectivity)
+	}
+	s.connectivity = connectivity
+	return nil
+}
+
+// GetConnectivity returns which coords are next to each other.
+func (s *Surface) GetConnectivity() Connectivity {
+	return s.connectivity
+}
+
+// Wrap returns the coord on the surface that the given coord ends up at when moving off the edges of a wrapped
+// surface. For surfaces that are not wrapped, the given coord is returned as is.
+func (s *Surface) Wrap(coord Coord) Coord {
+	if !s.wrapped || s.width == 0 || s.height == 0 {
+		return coord
+	}
+	return Coord{mod(coord.X, s.width), mod(coord.Y, s.height)}
+}
+
+// ManhattanDistance returns the smallest number of horizontal and vertical steps between the given coords, ignoring
+// any obstacles. On wrapped surfaces, steps across the edges are taken into account.
+func (s *Surface) ManhattanDistance(from, to Coord) int {
+	xDiff, yDiff := s.getDiffs(from, to)
+	return xDiff + yDiff
+}
+
+// CountStepsIgnoringObstacles returns the smallest number of steps between the given coords, ignoring any obstacles.
+// It takes into account both the connectivity of the surface and whether it is wrapped.
+func (s *Surface) CountStepsIgnoringObstacles(from, to Coord) int {
+	xDiff, yDiff := s.getDiffs(from, to)
+	if s.connectivity != EightConnected {
+		return xDiff + yDiff
+	}
+	if xDiff > yDiff {
+		return xDiff
 	}
+	return yDiff
+}
+
+// getDiffs returns the smallest horizontal and vertical difference between the given coords.
+// On wrapped surfaces, differences across the edges are taken into account.
+func (s *Surface) getDiffs(from, to Coord) (int, int) {
+	if !s.wrapped {
+		return abs(from.X - to.X), abs(from.Y - to.Y)
+	}
+	from, to = s.Wrap(from), s.Wrap(to)
+	xDiff := abs(from.X - to.X)
+	if s.width-xDiff < xDiff {
+		xDiff = s.width - xDiff
+	}
+	yDiff := abs(from.Y - to.Y)
+	if s.height-yDiff < yDiff {
+		yDiff = s.height - yDiff
+	}
+	return xDiff, yDiff
 }
 
 // GetCenter returns the center of the surface. It will round down if the center coordinate is not a round number.
@@ -43,16 +140,13 @@ func (s *Surface) GetCenter() Coord {
 }
 
 // EachFilled returns each filled coord.
+//
+// Deprecated: Use Filled instead. EachFilled leaks a goroutine if the returned channel is not drained.
 func (s *Surface) EachFilled() <-chan Coord {
 	ch := make(chan Coord)
 	go func() {
-		for x := 0; x < s.width; x++ {
-			for y := 0; y < s.height; y++ {
-				_, ok := s.surface[x][y]
-				if ok {
-					ch <- Coord{x, y}
-				}
-			}
+		for coord := range s.Filled() {
+			ch <- coord
 		}
 		close(ch)
 	}()
@@ -62,7 +156,7 @@ func (s *Surface) EachFilled() <-chan Coord {
 // GetFilled returns all filled coords.
 func (s *Surface) GetFilled() Coords {
 	var filled Coords
-	for coord := range s.EachFilled() {
+	for coord := range s.Filled() {
 		filled = append(filled, coord)
 	}
 	return filled
@@ -71,7 +165,7 @@ func (s *Surface) GetFilled() Coords {
 // CountFilled returns the number of filled coords.
 func (s *Surface) CountFilled() int {
 	var num int
-	for range s.EachFilled() {
+	for range s.Filled() {
 		num++
 	}
 	return num
@@ -95,78 +189,242 @@ func (s *Surface) Fits(coord Coord) bool {
 // Remove removes (unfills) the given coords from the surface.
 func (s *Surface) Remove(coords ...Coord) {
 	for _, coord := range coords {
-		delete(s.surface[coord.X], coord.Y)
+		coord = s.Wrap(coord)
+		if !s.Fits(coord) {
+			continue
+		}
+		s.removeAt(s.index(coord))
+	}
+}
+
+// removeAt removes the coord at the given index of the cells. See Remove.
+func (s *Surface) removeAt(i int) {
+	// Keep the cost and cell, as they do not depend on whether the coord is filled.
+	v := s.cells[i]
+	s.cells[i] = coordVal{
+		cost:  v.cost,
+		kind:  v.kind,
+		owner: v.owner,
 	}
 }
 
 // Fill fills the given coords.
 func (s *Surface) Fill(coords ...Coord) {
 	for _, coord := range coords {
-		// If already filled, don't flood again.
-		// We don't want to overwrite the value.
-		if s.IsFilled(coord) {
+		coord = s.Wrap(coord)
+		if !s.Fits(coord) {
+			continue
+		}
+		s.fillAt(s.index(coord))
+	}
+}
+
+// fillAt fills the coord at the given index of the cells. See Fill.
+func (s *Surface) fillAt(i int) {
+	// If already filled, don't flood again.
+	// We don't want to overwrite the value.
+	// Coords that are filled only until a given step are filled forever from now on.
+	v := s.cells[i]
+	if v.isFilled && v.freeAt == 0 {
+		return
+	}
+	s.cells[i] = coordVal{
+		isFilled: true,
+		cost:     v.cost,
+		kind:     v.kind,
+		owner:    v.owner,
+	}
+}
+
+// FillUntil fills the given coords until the given step, after which they are free again, for example for the body
+// of a snake, of which the tail moves away. Only the flood methods that take time into account, such as
+// CountStepsOverTime, treat the coords as free from that step on. Everything else treats them as filled.
+// Coords that are already filled forever stay filled forever. Coords that are already filled until a later step stay
+// filled until that later step.
+func (s *Surface) FillUntil(step int, coords ...Coord) {
+	if step < 1 {
+		return
+	}
+	for _, coord := range coords {
+		coord = s.Wrap(coord)
+		if !s.Fits(coord) {
 			continue
 		}
-		s.surface[coord.X][coord.Y] = coordVal{
+		v := s.cells[s.index(coord)]
+		if v.isFilled && (v.freeAt == 0 || v.freeAt >= step) {
+			continue
+		}
+		s.cells[s.index(coord)] = coordVal{
 			isFilled: true,
+			cost:     v.cost,
+			freeAt:   step,
+			kind:     v.kind,
+			owner:    v.owner,
+		}
+	}
+}
+
+// IsFilledAt returns true if the given coord is filled at the given step, or does not fit on the surface.
+// See FillUntil.
+func (s *Surface) IsFilledAt(coord Coord, step int) bool {
+	coord = s.Wrap(coord)
+	if !s.Fits(coord) {
+		return true
+	}
+	v := s.cells[s.index(coord)]
+	return v.isFilled && (v.freeAt == 0 || step < v.freeAt)
+}
+
+// SetCost sets the cost of moving onto the given coords, for example to make hazards more expensive to pass through
+// than other coords. By default each coord costs 1. Costs lower than 1 are set to 1.
+// Filling or removing a coord does not change its cost.
+func (s *Surface) SetCost(cost int, coords ...Coord) {
+	if cost < 1 {
+		cost = 1
+	}
+	for _, coord := range coords {
+		coord = s.Wrap(coord)
+		if !s.Fits(coord) {
+			continue
 		}
+		s.cells[s.index(coord)].cost = cost
 	}
 }
 
+// GetCost returns the cost of moving onto the given coord. Returns -1 if the coord does not fit on the surface.
+func (s *Surface) GetCost(coord Coord) int {
+	coord = s.Wrap(coord)
+	if !s.Fits(coord) {
+		return -1
+	}
+	if cost := s.cells[s.index(coord)].cost; cost > 0 {
+		return cost
+	}
+	return 1
+}
+
 // IsFilled returns true if the given coord is filled or does not fit on the surface.
+// On wrapped surfaces, coords that do not fit are wrapped first.
 func (s *Surface) IsFilled(coord Coord) bool {
+	coord = s.Wrap(coord)
 	if !s.Fits(coord) {
 		return true
 	}
-	v, ok := s.surface[coord.X][coord.Y]
-	return ok && v.isFilled
+	return s.cells[s.index(coord)].isFilled
 }
 
 // Clone returns a clone of the surface.
 func (s *Surface) Clone() *Surface {
-	clone := NewSurface(s.width, s.height)
-	for coord := range s.EachFilled() {
-		clone.forceFill(coord)
+	clone := &Surface{
+		width:        s.width,
+		height:       s.height,
+		wrapped:      s.wrapped,
+		connectivity: s.connectivity,
+		cells:        make([]coordVal, len(s.cells)),
 	}
+	copy(clone.cells, s.cells)
 	return clone
 }
 
+// index returns the index of the given coord in the cells of the surface.
+// It does not check if the coord fits on the surface.
+func (s *Surface) index(coord Coord) int {
+	return coord.Y*s.width + coord.X
+}
+
+// coordAt returns the coord that belongs to the given index in the cells of the surface.
+func (s *Surface) coordAt(i int) Coord {
+	return Coord{i % s.width, i / s.width}
+}
+
 func (s *Surface) hasDistance(coord Coord) bool {
-	v, ok := s.surface[coord.X][coord.Y]
+	v, ok := s.getValue(coord)
 	return ok && v.distance > 0
 }
 
 func (s *Surface) getDistance(coord Coord) int {
-	if v, ok := s.surface[coord.X][coord.Y]; ok {
+	if v, ok := s.getValue(coord); ok {
 		return v.distance
 	}
 	return -1
 }
 
+// getValue returns the value at the given coord. It returns false if the coord does not fit on the surface, or if it
+// is neither filled nor has a distance.
 func (s *Surface) getValue(coord Coord) (coordVal, bool) {
-	v, ok := s.surface[coord.X][coord.Y]
-	return v, ok
+	if !s.Fits(coord) {
+		return coordVal{}, false
+	}
+	v := s.cells[s.index(coord)]
+	return v, v.isFilled || v.distance > 0
 }
 
 func (s *Surface) setDistance(coord Coord, distance int) {
-	v := s.surface[coord.X][coord.Y]
-	v.distance = distance
-	s.surface[coord.X][coord.Y] = v
+	s.cells[s.index(coord)].distance = distance
 }
 
-// forceFill does a regular flood, but does not check if the coords actually fit on the surface,
-// which is slightly faster.
-func (s *Surface) forceFill(coords ...Coord) {
-	for _, coord := range coords {
-		v := s.surface[coord.X][coord.Y]
-		v.isFilled = true
-		s.surface[coord.X][coord.Y] = v
+// clearDistances removes the distances of all coords, leaving only the filled coords.
+func (s *Surface) clearDistances() {
+	for i := range s.cells {
+		s.cells[i].distance = 0
+	}
+}
+
+// GetCoordsAround returns all coords that are next to the given coord, taking into account the connectivity of the
+// surface. On wrapped surfaces, the coords are wrapped. The coords are not guaranteed to fit on the surface.
+func (s *Surface) GetCoordsAround(coord Coord) Coords {
+	var coordsAround Coords
+	if s.connectivity == EightConnected {
+		coordsAround = coord.GetCoordsAroundWithDiagonals()
+	} else {
+		coordsAround = coord.GetCoordsAround()
+	}
+	if s.wrapped {
+		for i, c := range coordsAround {
+			coordsAround[i] = s.Wrap(c)
+		}
+	}
+	return coordsAround
+}
+
+// getCoordInDirection returns the first coord in the given direction from the given coord, wrapping it if the surface
+// is wrapped.
+func (s *Surface) getCoordInDirection(coord Coord, d Direction) Coord {
+	return s.Wrap(coord.GetCoordInDirection(d))
+}
+
+// getDirectionTo returns the direction in which `to` lies, if it is directly next to `from`.
+// Returns false if `to` is not directly next to `from`.
+func (s *Surface) getDirectionTo(from, to Coord) (Direction, bool) {
+	to = s.Wrap(to)
+	for _, d := range s.getDirections() {
+		if s.getCoordInDirection(from, d) == to {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// getDirections returns the directions in which can be moved, taking into account the connectivity of the surface.
+func (s *Surface) getDirections() []Direction {
+	if s.connectivity == EightConnected {
+		return GetAllDirectionsWithDiagonals()
+	}
+	return GetAllDirections()
+}
+
+// connects returns true if the given coords are the same or directly next to each other.
+func (s *Surface) connects(a, b Coord) bool {
+	if s.Wrap(a) == s.Wrap(b) {
+		return true
 	}
+	_, ok := s.getDirectionTo(s.Wrap(a), b)
+	return ok
 }
 
 func (s *Surface) getCoordsFilledAround(coord Coord) Coords {
-	coordsAround := coord.GetCoordsAround()
-	filled := make(Coords, 0, 4)
+	coordsAround := s.GetCoordsAround(coord)
+	filled := make(Coords, 0, len(coordsAround))
 	for _, c := range coordsAround {
 		if s.IsFilled(c) {
 			filled = append(filled, c)
@@ -21,16 +21,12 @@ func TestNewSurface(t *testing.T) {
 			description: "",
 			input: input{
 				width:  3,
-				height: 3,
+				height: 2,
 			},
 			expected: &Surface{
 				width:  3,
-				height: 3,
-				surface: surfaceMap{
-					0: {},
-					1: {},
-					2: {},
-				},
+				height: 2,
+				cells:  make([]coordVal, 6),
 			},
 		},
 	}
@@ -42,8 +38,8 @@ func TestNewSurface(t *testing.T) {
 
 				So(res.width, ShouldEqual, tc.expected.width)
 				So(res.height, ShouldEqual, tc.expected.height)
-				So(res.surface, ShouldResemble, tc.expected.surface)
-				So(len(res.surface), ShouldEqual, tc.input.width)
+				So(res.cells, ShouldResemble, tc.expected.cells)
+				So(len(res.cells), ShouldEqual, tc.input.width*tc.input.height)
 			})
 		}
 	})
@@ -54,45 +50,18 @@ func TestSurface_fill(t *testing.T) {
 	testCases := []struct {
 		description string
 		input       []Coord
-		expected    surfaceMap
 	}{
 		{
 			description: "sets all corners correctly",
 			input:       []Coord{{0, 0}, {0, 2}, {2, 2}, {2, 0}},
-			expected: surfaceMap{
-				0: {
-					0: coordVal{},
-					2: coordVal{},
-				},
-				1: {},
-				2: {
-					0: coordVal{},
-					2: coordVal{},
-				},
-			},
 		},
 		{
 			description: "somewhere in the middle",
 			input:       []Coord{{1, 1}, {1, 2}, {2, 2}},
-			expected: surfaceMap{
-				0: {},
-				1: {
-					1: coordVal{},
-					2: coordVal{},
-				},
-				2: {
-					2: coordVal{},
-				},
-			},
 		},
 		{
 			description: "does not set coords that are out of bounds",
 			input:       []Coord{{-1, -1}, {4, 4}},
-			expected: surfaceMap{
-				0: {},
-				1: {},
-				2: {},
-			},
 		},
 	}
 
@@ -112,11 +81,11 @@ func TestSurface_fill(t *testing.T) {
 							}
 						}
 
-						_, ok := s.surface[x][y]
+						v := s.cells[s.index(Coord{x, y})]
 						if hasCoord {
-							So(ok, ShouldBeTrue)
+							So(v.isFilled, ShouldBeTrue)
 						} else {
-							So(ok, ShouldBeFalse)
+							So(v.isFilled, ShouldBeFalse)
 						}
 					}
 				}
@@ -127,115 +96,253 @@ func TestSurface_fill(t *testing.T) {
 
 func Test_StandardSurface_Remove(t *testing.T) {
 	type input struct {
-		surface surfaceMap
-		remove  []Coord
+		filled Coords
+		remove []Coord
 	}
 	testCases := []struct {
 		description string
 		input       input
-		expected    surfaceMap
+		expected    Coords
 	}{
 		{
 			description: "removes correctly",
 			input: input{
-				surface: surfaceMap{
-					1: map[int]coordVal{
-						2: {},
-					},
-				},
+				filled: Coords{{1, 2}},
 				remove: []Coord{{1, 2}},
 			},
-			expected: surfaceMap{
-				1: map[int]coordVal{},
-			},
+			expected: nil,
 		},
 		{
 			description: "does nothing when given coord to remove does not exist",
 			input: input{
-				surface: surfaceMap{
-					4: map[int]coordVal{
-						9: {},
-					},
-				},
+				filled: Coords{{4, 9}},
 				remove: []Coord{{1, 2}},
 			},
-			expected: surfaceMap{
-				4: map[int]coordVal{
-					9: {},
-				},
+			expected: Coords{{4, 9}},
+		},
+		{
+			description: "does nothing when given coord to remove does not fit",
+			input: input{
+				filled: Coords{{4, 9}},
+				remove: []Coord{{-1, 2}, {10, 10}},
 			},
+			expected: Coords{{4, 9}},
 		},
 		{
 			description: "removes from the middle correctly",
 			input: input{
-				surface: surfaceMap{
-					1: map[int]coordVal{
-						2: {},
-					},
-					4: map[int]coordVal{
-						5: {},
-						6: {},
-						9: {},
-					},
-					8: map[int]coordVal{
-						9: {},
-					},
-				},
+				filled: Coords{{1, 2}, {4, 5}, {4, 6}, {4, 9}, {8, 9}},
 				remove: []Coord{{4, 6}},
 			},
-			expected: surfaceMap{
-				1: map[int]coordVal{
-					2: {},
-				},
-				4: map[int]coordVal{
-					5: {},
-					9: {},
-				},
-				8: map[int]coordVal{
-					9: {},
-				},
-			},
+			expected: Coords{{1, 2}, {4, 5}, {4, 9}, {8, 9}},
 		},
 		{
 			description: "removes multiple correctly",
 			input: input{
-				surface: surfaceMap{
-					1: map[int]coordVal{
-						2: {},
-					},
-					4: map[int]coordVal{
-						5: {},
-						6: {},
-						9: {},
-					},
-					8: map[int]coordVal{
-						9: {},
-					},
-				},
+				filled: Coords{{1, 2}, {4, 5}, {4, 6}, {4, 9}, {8, 9}},
 				remove: []Coord{{4, 6}, {8, 9}},
 			},
-			expected: surfaceMap{
-				1: map[int]coordVal{
-					2: {},
-				},
-				4: map[int]coordVal{
-					5: {},
-					9: {},
-				},
-				8: map[int]coordVal{},
-			},
+			expected: Coords{{1, 2}, {4, 5}, {4, 9}},
 		},
 	}
 
 	Convey("Surface.Remove()", t, func() {
 		for i, tc := range testCases {
 			Convey(fmt.Sprintf("%d: %s", i, tc.description), func() {
-				s := NewSurface(0, 0)
-				s.surface = tc.input.surface
+				s := NewSurface(10, 10)
+				s.Fill(tc.input.filled...)
 				s.Remove(tc.input.remove...)
 
-				So(tc.input.surface, ShouldResemble, tc.expected)
+				So(s.GetFilled(), ShouldResemble, tc.expected)
 			})
 		}
 	})
 }
+
+func TestSurface_Clone(t *testing.T) {
+	Convey("Surface.Clone()", t, func() {
+		s := NewSurface(3, 3)
+		s.Fill(Coord{0, 0}, Coord{2, 1})
+
+		clone := s.Clone()
+
+		Convey("Returns a surface with the same coords filled", func() {
+			So(clone.width, ShouldEqual, s.width)
+			So(clone.height, ShouldEqual, s.height)
+			So(clone.GetFilled(), ShouldResemble, s.GetFilled())
+		})
+
+		Convey("Does not share its state with the original", func() {
+			clone.Fill(Coord{1, 1})
+			s.Remove(Coord{0, 0})
+
+			So(clone.IsFilled(Coord{0, 0}), ShouldBeTrue)
+			So(s.IsFilled(Coord{1, 1}), ShouldBeFalse)
+		})
+	})
+}
+
+func TestSurface_SetCost(t *testing.T) {
+	Convey("Surface.SetCost()", t, func() {
+		s := NewSurface(3, 3)
+
+		Convey("Coords cost 1 by default", func() {
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 1)
+		})
+
+		Convey("Sets the cost of the given coords", func() {
+			s.SetCost(5, Coord{1, 1}, Coord{2, 2})
+
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 5)
+			So(s.GetCost(Coord{2, 2}), ShouldEqual, 5)
+			So(s.GetCost(Coord{0, 0}), ShouldEqual, 1)
+		})
+
+		Convey("Sets costs lower than 1 to 1", func() {
+			s.SetCost(-3, Coord{1, 1})
+
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 1)
+		})
+
+		Convey("Keeps the cost when filling and removing", func() {
+			s.SetCost(5, Coord{1, 1})
+			s.Fill(Coord{1, 1})
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 5)
+
+			s.Remove(Coord{1, 1})
+			So(s.GetCost(Coord{1, 1}), ShouldEqual, 5)
+		})
+
+		Convey("Returns -1 for coords that do not fit", func() {
+			So(s.GetCost(Coord{3, 3}), ShouldEqual, -1)
+		})
+	})
+}
+
+func TestSurface_Wrapped(t *testing.T) {
+	Convey("Wrapped surface", t, func() {
+		s := NewWrappedSurface(5, 3)
+
+		Convey("Wrap()", func() {
+			So(s.Wrap(Coord{-1, 0}), ShouldResemble, Coord{4, 0})
+			So(s.Wrap(Coord{5, 3}), ShouldResemble, Coord{0, 0})
+			So(s.Wrap(Coord{2, -4}), ShouldResemble, Coord{2, 2})
+			So(s.Wrap(Coord{2, 1}), ShouldResemble, Coord{2, 1})
+
+			Convey("Does not wrap coords on surfaces that are not wrapped", func() {
+				So(NewSurface(5, 3).Wrap(Coord{-1, 0}), ShouldResemble, Coord{-1, 0})
+			})
+		})
+
+		Convey("Fill() and IsFilled() wrap coords", func() {
+			s.Fill(Coord{-1, 0})
+
+			So(s.IsFilled(Coord{4, 0}), ShouldBeTrue)
+			So(s.IsFilled(Coord{-1, 3}), ShouldBeTrue)
+			So(s.IsFilled(Coord{0, 0}), ShouldBeFalse)
+			So(s.CountFilled(), ShouldEqual, 1)
+		})
+
+		Convey("ManhattanDistance() takes steps across the edges", func() {
+			So(s.ManhattanDistance(Coord{0, 0}, Coord{4, 0}), ShouldEqual, 1)
+			So(s.ManhattanDistance(Coord{0, 0}, Coord{2, 2}), ShouldEqual, 3)
+			So(NewSurface(5, 3).ManhattanDistance(Coord{0, 0}, Coord{4, 0}), ShouldEqual, 4)
+		})
+
+		Convey("Clone() keeps the surface wrapped", func() {
+			So(s.Clone().IsWrapped(), ShouldBeTrue)
+		})
+	})
+}
+
+func TestSurface_Connectivity(t *testing.T) {
+	Convey("Surface connectivity", t, func() {
+		s := NewSurface(3, 3)
+
+		Convey("Is four connected by default", func() {
+			So(s.GetConnectivity(), ShouldEqual, FourConnected)
+			So(s.GetCoordsAround(Coord{1, 1}), ShouldHaveLength, 4)
+			So(s.CountStepsIgnoringObstacles(Coord{0, 0}, Coord{2, 2}), ShouldEqual, 4)
+		})
+
+		Convey("If eight connected", func() {
+			s.SetConnectivity(EightConnected)
+
+			Convey("Includes diagonal coords around", func() {
+				coords := s.GetCoordsAround(Coord{1, 1})
+
+				So(coords, ShouldHaveLength, 8)
+				So(coords.Contains(Coord{0, 0}), ShouldBeTrue)
+				So(coords.Contains(Coord{2, 2}), ShouldBeTrue)
+			})
+
+			Convey("Counts diagonal steps", func() {
+				So(s.CountStepsIgnoringObstacles(Coord{0, 0}, Coord{2, 2}), ShouldEqual, 2)
+				So(s.CountStepsIgnoringObstacles(Coord{0, 0}, Coord{2, 1}), ShouldEqual, 2)
+				So(s.ManhattanDistance(Coord{0, 0}, Coord{2, 2}), ShouldEqual, 4)
+			})
+
+			Convey("Keeps the connectivity when cloned", func() {
+				So(s.Clone().GetConnectivity(), ShouldEqual, EightConnected)
+			})
+		})
+
+		Convey("Rejects other connectivities", func() {
+			for _, connectivity := range []Connectivity{0, 6, -4} {
+				err := s.SetConnectivity(connectivity)
+
+				So(err, ShouldNotBeNil)
+				So(s.GetConnectivity(), ShouldEqual, FourConnected)
+			}
+		})
+	})
+}
+
+func TestSurface_FillUntil(t *testing.T) {
+	Convey("Surface.FillUntil()", t, func() {
+		s := NewSurface(3, 3)
+		s.FillUntil(2, Coord{1, 1})
+
+		Convey("Fills the coords", func() {
+			So(s.IsFilled(Coord{1, 1}), ShouldBeTrue)
+		})
+
+		Convey("Frees the coords at the given step", func() {
+			So(s.IsFilledAt(Coord{1, 1}, 0), ShouldBeTrue)
+			So(s.IsFilledAt(Coord{1, 1}, 1), ShouldBeTrue)
+			So(s.IsFilledAt(Coord{1, 1}, 2), ShouldBeFalse)
+			So(s.IsFilledAt(Coord{1, 1}, 3), ShouldBeFalse)
+		})
+
+		Convey("Keeps the latest step if filled again", func() {
+			s.FillUntil(4, Coord{1, 1})
+			s.FillUntil(3, Coord{1, 1})
+
+			So(s.IsFilledAt(Coord{1, 1}, 3), ShouldBeTrue)
+			So(s.IsFilledAt(Coord{1, 1}, 4), ShouldBeFalse)
+		})
+
+		Convey("Does not free coords that are filled forever", func() {
+			s.Fill(Coord{0, 0})
+			s.FillUntil(2, Coord{0, 0})
+
+			So(s.IsFilledAt(Coord{0, 0}, 5), ShouldBeTrue)
+		})
+
+		Convey("Fills coords forever when filled with Fill()", func() {
+			s.Fill(Coord{1, 1})
+
+			So(s.IsFilledAt(Coord{1, 1}, 5), ShouldBeTrue)
+		})
+
+		Convey("Frees coords when removed", func() {
+			s.Remove(Coord{1, 1})
+
+			So(s.IsFilledAt(Coord{1, 1}, 0), ShouldBeFalse)
+		})
+
+		Convey("Treats coords that do not fit as filled", func() {
+			So(s.IsFilledAt(Coord{3, 3}, 5), ShouldBeTrue)
+		})
+	})
+}
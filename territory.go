@@ -0,0 +1,101 @@
+package plane
+
+// Territory is the part of a surface that a source, such as a snake's head, can reach before any other source.
+type Territory struct {
+	Source Coord
+	// Coords are the unfilled coords that the source reaches before any other source.
+	Coords Coords
+	// Tied are the unfilled coords that the source reaches at the same time as one or more other sources.
+	Tied Coords
+}
+
+// Size returns the number of coords that the source reaches before any other source.
+func (t Territory) Size() int {
+	return len(t.Coords)
+}
+
+// GetTerritories floods the surface breadth-first from all given sources at once, and returns for each source, in the
+// same order, the unfilled coords that it reaches first. Like the flood methods, it does not flood the sources
+// themselves, and the flood does not pass through sources or filled coords.
+// It does not change the surface.
+func (f *FloodFiller) GetTerritories(sources ...Coord) []Territory {
+	territories := make([]Territory, len(sources))
+	// owners holds for each coord the indexes of the sources that reach it first, or nil if it was not reached yet.
+	owners := make([][]int, f.s.TotalSurface())
+	distances := make([]int, f.s.TotalSurface())
+	isSource := make([]bool, f.s.TotalSurface())
+	for i, source := range sources {
+		territories[i].Source = source
+		if source = f.s.Wrap(source); f.s.Fits(source) {
+			isSource[f.s.index(source)] = true
+		}
+	}
+
+	// reach marks coord `c` as reached by the given owners at the given distance, and returns true if it was not
+	// reached before.
+	reach := func(c Coord, by []int, distance int) bool {
+		if !f.s.Fits(c) || f.isBlocked(c) {
+			return false
+		}
+		i := f.s.index(c)
+		if isSource[i] {
+			return false
+		}
+		if owners[i] == nil {
+			owners[i] = append([]int{}, by...)
+			distances[i] = distance
+			return true
+		}
+		if distances[i] == distance {
+			for _, owner := range by {
+				if !containsInt(owners[i], owner) {
+					owners[i] = append(owners[i], owner)
+				}
+			}
+		}
+		return false
+	}
+
+	queue := make(Coords, 0, len(sources)*4)
+	for i, source := range sources {
+		for _, start := range f.getStarts(f.s.Wrap(source)) {
+			if reach(start, []int{i}, 1) {
+				queue = append(queue, start)
+			}
+		}
+	}
+	// The flood is breadth-first, so all coords at a given distance are processed before the coords that are further
+	// away. This means that the owners of a coord are known by the time it is processed.
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		curIndex := f.s.index(cur)
+		for _, c := range f.s.GetCoordsAround(cur) {
+			if reach(c, owners[curIndex], distances[curIndex]+1) {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	for i, coordOwners := range owners {
+		if len(coordOwners) == 0 {
+			continue
+		}
+		if len(coordOwners) == 1 {
+			territories[coordOwners[0]].Coords = append(territories[coordOwners[0]].Coords, f.s.coordAt(i))
+			continue
+		}
+		for _, owner := range coordOwners {
+			territories[owner].Tied = append(territories[owner].Tied, f.s.coordAt(i))
+		}
+	}
+	return territories
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package plane
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func Test_FloodFiller_GetTerritories(t *testing.T) {
+	Convey("FloodFiller.GetTerritories()", t, func() {
+		s := NewSurface(5, 1)
+		filler := NewFloodFiller(s)
+
+		Convey("With a single source", func() {
+			Convey("Returns all reachable coords", func() {
+				territories := filler.GetTerritories(Coord{0, 0})
+
+				So(territories, ShouldHaveLength, 1)
+				So(territories[0].Source, ShouldResemble, Coord{0, 0})
+				So(territories[0].Coords, ShouldResemble, Coords{{1, 0}, {2, 0}, {3, 0}, {4, 0}})
+				So(territories[0].Tied, ShouldBeEmpty)
+			})
+		})
+
+		Convey("With sources at equal distance of the middle", func() {
+			// A . . . B
+			Convey("Splits the surface, with the middle tied", func() {
+				territories := filler.GetTerritories(Coord{0, 0}, Coord{4, 0})
+
+				So(territories[0].Coords, ShouldResemble, Coords{{1, 0}})
+				So(territories[0].Tied, ShouldResemble, Coords{{2, 0}})
+				So(territories[1].Coords, ShouldResemble, Coords{{3, 0}})
+				So(territories[1].Tied, ShouldResemble, Coords{{2, 0}})
+			})
+		})
+
+		Convey("With sources at unequal distance of the middle", func() {
+			// A . . B .
+			Convey("Gives the contested coords to the closest source", func() {
+				territories := filler.GetTerritories(Coord{0, 0}, Coord{3, 0})
+
+				So(territories[0].Coords, ShouldResemble, Coords{{1, 0}})
+				So(territories[0].Tied, ShouldBeEmpty)
+				So(territories[1].Coords, ShouldResemble, Coords{{2, 0}, {4, 0}})
+				So(territories[1].Tied, ShouldBeEmpty)
+				So(territories[1].Size(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("With obstacles", func() {
+			// (A, B = sources, x = filled)
+			// . . . x .
+			// A . . x B
+			// . . . x .
+			s := NewSurface(5, 3)
+			s.fillRows([][]int{
+				{0, 0, 0, 1, 0},
+				{0, 0, 0, 1, 0},
+				{0, 0, 0, 1, 0},
+			})
+			filler := NewFloodFiller(s)
+
+			Convey("Does not pass through filled coords", func() {
+				territories := filler.GetTerritories(Coord{0, 1}, Coord{4, 1})
+
+				So(territories[0].Size(), ShouldEqual, 8)
+				So(territories[0].Tied, ShouldBeEmpty)
+				So(territories[1].Coords, ShouldResemble, Coords{{4, 0}, {4, 2}})
+				So(territories[1].Tied, ShouldBeEmpty)
+			})
+
+			Convey("Does not change the surface", func() {
+				before := s.Clone()
+
+				filler.GetTerritories(Coord{0, 1}, Coord{4, 1})
+
+				So(s, ShouldResemble, before)
+			})
+		})
+
+		Convey("With tied coords leading to more coords", func() {
+			// (A, B = sources)
+			// . . .
+			// . . .
+			// A . B
+			s := NewSurface(3, 3)
+			filler := NewFloodFiller(s)
+
+			Convey("Ties the coords behind the tied coords too", func() {
+				territories := filler.GetTerritories(Coord{0, 0}, Coord{2, 0})
+
+				So(territories[0].Coords, ShouldResemble, Coords{{0, 1}, {0, 2}})
+				So(territories[0].Tied, ShouldResemble, Coords{{1, 0}, {1, 1}, {1, 2}})
+				So(territories[1].Coords, ShouldResemble, Coords{{2, 1}, {2, 2}})
+				So(territories[1].Tied, ShouldResemble, Coords{{1, 0}, {1, 1}, {1, 2}})
+			})
+		})
+	})
+}